@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 
+	"nwcli/pkg/cachesync"
+	"nwcli/pkg/config"
 	"nwcli/pkg/news"
 	"nwcli/pkg/renderer"
 
@@ -22,9 +24,18 @@ clear the cache if needed.`,
 var cacheStatsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "📊 Show cache statistics",
-	Long:  `Display statistics about the local article cache including number of articles, sources, and storage information.`,
+	Long: `Display statistics about the local article cache: the number of articles
+per source, country and category, when they were published by hour and by
+day, the size on disk, the oldest and newest articles, and the fetch health
+of every source (whether it is within its TTL, last successful fetch,
+failures in a row and average items per fetch).
+
+Use --format json for all of it as one object.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		format, _ := cmd.Flags().GetString("format")
+		format, err := formatFlag(cmd)
+		if err != nil {
+			return err
+		}
 		verbose, _ := cmd.Flags().GetBool("verbose")
 
 		if verbose {
@@ -35,22 +46,27 @@ var cacheStatsCmd = &cobra.Command{
 		cache := news.NewArticleCache()
 		articles := cache.GetCachedArticles(0) // Get all
 
-		if len(articles) == 0 {
+		if len(articles) == 0 && format == "markdown" {
 			fmt.Println("📭 Cache is empty")
 			fmt.Println("   Run 'nwcli latest' to populate the cache")
 			return nil
 		}
 
-		switch format {
-		case "json":
-			// TODO: Implement JSON output
-			fmt.Println("JSON output not yet implemented")
-			return nil
-		case "plain":
-			return renderCacheStatsPlain(articles, cache)
-		default: // markdown
-			return renderCacheStatsMarkdown(articles, cache)
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		freshness, err := cacheFreshness(cmd, cfg.Cache)
+		if err != nil {
+			return err
 		}
+
+		stats := renderer.NewStats(articles)
+		stats.DiskSize = cache.DiskSize()
+		stats.AddHealth(news.LoadFeedStates(), freshness)
+		return renderOutput(format, func(r renderer.Renderer) (string, error) {
+			return r.RenderStats(stats)
+		})
 	},
 }
 
@@ -73,48 +89,89 @@ var cacheClearCmd = &cobra.Command{
 	},
 }
 
-func init() {
-	rootCmd.AddCommand(cacheCmd)
-	cacheCmd.AddCommand(cacheStatsCmd)
-	cacheCmd.AddCommand(cacheClearCmd)
+var cacheExportCmd = &cobra.Command{
+	Use:   "export <archive.tar.zst|archive.tar.gz>",
+	Short: "📦 Export the cache to an archive",
+	Long: `Write all cached articles, the fetch history of the sources and the
+reading positions to a tar archive that 'nwcli cache import' can merge
+into the cache on another machine.
+
+Archives ending in .zst are zstd compressed, others gzip compressed.
+
+Examples:
+  nwcli cache export news.tar.zst
+  nwcli cache export news.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, err := cachesync.Export(args[0], news.NewArticleCache())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Exported %d articles to %s\n", manifest.Articles, args[0])
+		return nil
+	},
 }
 
-func renderCacheStatsPlain(articles []news.Article, cache *news.ArticleCache) error {
-	fmt.Println("Cache Statistics")
-	fmt.Println("================")
-	fmt.Printf("Total articles: %d\n", len(articles))
-
-	if cache.IsStale() {
-		fmt.Println("Status: Stale (older than 1 hour)")
-	} else {
-		fmt.Println("Status: Fresh")
-	}
-
-	// Count by source
-	sourceCount := make(map[string]int)
-	for _, article := range articles {
-		sourceCount[article.Source]++
-	}
-
-	fmt.Println("\nArticles by source:")
-	for source, count := range sourceCount {
-		fmt.Printf("  %s: %d\n", source, count)
-	}
-
-	return nil
+var cacheImportCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "📥 Merge an exported archive into the cache",
+	Long: `Merge the articles of an archive written by 'nwcli cache export'
+into the cache, along with the fetch history of the sources and the
+reading positions. zstd and gzip archives are both accepted.
+
+Articles in both are combined: the copy updated or published last wins,
+categories of both copies are kept, and full article text is kept when
+only one copy has it. For the fetch history and reading positions the
+latest entry wins.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, result, err := cachesync.Import(args[0], news.NewArticleCache())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Imported %d articles from %s (%s): %d added, %d updated\n",
+			manifest.Articles, manifest.Device, manifest.Created.Format("2006-01-02 15:04"),
+			result.Added, result.Updated)
+		return nil
+	},
 }
 
-func renderCacheStatsMarkdown(articles []news.Article, cache *news.ArticleCache) error {
-	renderer, err := renderer.NewMarkdownRenderer()
-	if err != nil {
-		return fmt.Errorf("failed to create renderer: %w", err)
-	}
+var cacheSyncCmd = &cobra.Command{
+	Use:   "sync <dir>",
+	Short: "🔄 Sync the cache through a shared folder",
+	Long: `Merge the cache with a folder shared between machines, for example
+with Syncthing or a network drive.
+
+Each machine appends the articles it added or changed and its fetch
+history to its own change log in the folder and merges the changes of the
+other machines, with the same rules as 'nwcli cache import'. Logs are
+compacted once most of their entries are replaced by later edits. Run it on every machine, e.g. from
+cron, to share one cache between a laptop and a desktop.`,
+	Example: `  nwcli cache sync ~/Sync/nwcli`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := cachesync.Sync(args[0], news.NewArticleCache())
+		if err != nil {
+			return err
+		}
 
-	output, err := renderer.RenderStats(articles)
-	if err != nil {
-		return fmt.Errorf("failed to render stats: %w", err)
-	}
+		fmt.Printf("✅ Synced with %s as %s\n", args[0], cachesync.DeviceID())
+		fmt.Printf("   Pulled %d changes from %d devices (%d added, %d updated), pushed %d\n",
+			result.Pulled, result.Devices, result.Added, result.Updated, result.Pushed)
+		if result.Compacted {
+			fmt.Println("   Compacted this device's change log")
+		}
+		return nil
+	},
+}
 
-	fmt.Print(output)
-	return nil
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheExportCmd)
+	cacheCmd.AddCommand(cacheImportCmd)
+	cacheCmd.AddCommand(cacheSyncCmd)
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"nwcli/pkg/news"
+	"nwcli/pkg/tui"
 
 	"github.com/spf13/cobra"
 )
@@ -21,16 +22,36 @@ This creates a newspaper-like summary with:
 • Beautiful formatting with images
 • Time stamps and source attribution
 
-Perfect for your morning news routine!`,
+Perfect for your morning news routine!
+
+Use --format epub to write the digest as an EPUB book for e-readers,
+with the full text and images of every article, or --mail to send it by
+email with the SMTP settings in the config file.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		limit, _ := cmd.Flags().GetInt("limit")
-		format, _ := cmd.Flags().GetString("format")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		categories, _ := cmd.Flags().GetStringSlice("categories")
 		country, _ := cmd.Flags().GetString("country")
 		fullContent, _ := cmd.Flags().GetBool("full")
 		noPager, _ := cmd.Flags().GetBool("no-pager")
+		format, _ := cmd.Flags().GetString("format")
+		if format != formatEPUB {
+			var err error
+			if format, err = formatFlag(cmd); err != nil {
+				return err
+			}
+		}
+		layout, err := layoutFlag(cmd)
+		if err != nil {
+			return err
+		}
+		mediaType, _ := cmd.Flags().GetString("media")
+		sendMail, _ := cmd.Flags().GetBool("mail")
+
+		if err := validateMediaType(mediaType); err != nil {
+			return err
+		}
 
 		if verbose {
 			fmt.Printf("📰 Preparing your daily %s news digest", country)
@@ -41,11 +62,40 @@ Perfect for your morning news routine!`,
 		}
 
 		// Create news service with options
-		newsService := news.NewNewsServiceWithOptions(country, fullContent)
+		newsService, err := newNewsService(cmd, country, fullContent)
+		if err != nil {
+			return err
+		}
 
 		// Get today's articles
 		today := time.Now().Truncate(24 * time.Hour)
 
+		title := fmt.Sprintf("📰 Daily News Digest (%s) - %s",
+			strings.ToUpper(country),
+			time.Now().Format("Monday, January 2, 2006"))
+		if fullContent {
+			title += " - Full Articles"
+		}
+
+		// Interactive reader: start from today's cached articles and fetch
+		// in the background
+		if format == "markdown" && layout == layoutList && !sendMail && tui.ShouldUsePager(noPager) {
+			filter := func(article news.Article) bool {
+				return !article.Published.Before(today) &&
+					(len(categories) == 0 || hasAnyCategory(article, categories)) &&
+					(mediaType == "" || article.HasMedia(mediaType))
+			}
+			articles := organizeDigestArticles(cachedArticles(newsService, filter, 0), limit)
+			if len(articles) > 0 || !newsService.IsOffline() {
+				return tui.LaunchLiveTUI(articles, title, tui.LiveOptions{
+					Fetcher: liveFetcher(newsService),
+					Filter:  filter,
+					Limit:   limit,
+					Content: contentFetcher(newsService),
+				})
+			}
+		}
+
 		var allArticles []news.Article
 
 		if len(categories) > 0 {
@@ -76,6 +126,10 @@ Perfect for your morning news routine!`,
 			allArticles = articles
 		}
 
+		if mediaType != "" {
+			allArticles = news.FilterByMedia(allArticles, mediaType)
+		}
+
 		// Group articles by category for better digest structure
 		digestArticles := organizeDigestArticles(allArticles, limit)
 
@@ -83,21 +137,21 @@ Perfect for your morning news routine!`,
 			fmt.Printf("✅ Prepared digest with %d articles\n\n", len(digestArticles))
 		}
 
+		if sendMail {
+			return mailDigest(cmd, newsService, country, title, digestArticles)
+		}
+
 		// Render based on format
-		switch format {
-		case "json":
-			return renderJSON(digestArticles)
-		case "plain":
-			return renderPlain(digestArticles)
-		default: // markdown
-			title := fmt.Sprintf("📰 Daily News Digest (%s) - %s",
-				strings.ToUpper(country),
-				time.Now().Format("Monday, January 2, 2006"))
-			if fullContent {
-				title += " - Full Articles"
-			}
-			return renderMarkdownWithPager(digestArticles, title, noPager)
+		if format == formatEPUB {
+			return writeEPUB(cmd, newsService, country, "Daily News Digest", "digest", digestArticles)
 		}
+		if format != "markdown" {
+			return renderArticles(format, digestArticles, title)
+		}
+		if layout == layoutNewspaper {
+			return renderNewspaper(digestArticles, title)
+		}
+		return renderMarkdownWithPager(digestArticles, title, noPager, newsService)
 	},
 }
 
@@ -110,6 +164,23 @@ func init() {
 	digestCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
 	digestCmd.Flags().BoolP("full", "", false, "include full article content instead of summaries")
 	digestCmd.Flags().BoolP("no-pager", "", false, "disable interactive pager and output to stdout")
+	digestCmd.Flags().IntP("summary", "", 0, "add an N-sentence extractive summary to each article (default summary.sentences from the config file)")
+	digestCmd.Flags().StringP("media", "m", "", "only include articles with media of this type (audio, video)")
+	digestCmd.Flags().StringP("output", "o", "", "file to write with --format epub (default digest-YYYY-MM-DD.epub) or --mail --dry-run (default digest-YYYY-MM-DD.eml)")
+	addEPUBFlags(digestCmd)
+	digestCmd.Flags().Bool("mail", false, "send the digest by email using the mail settings in the config file")
+	digestCmd.Flags().Bool("dry-run", false, "with --mail, write the email to an .eml file instead of sending it")
+	digestCmd.Flags().StringSlice("to", []string{}, "with --mail, recipients instead of mail.to from the config file")
+}
+
+// hasAnyCategory reports whether article is tagged with one of categories
+func hasAnyCategory(article news.Article, categories []string) bool {
+	for _, category := range categories {
+		if article.HasCategory(category) {
+			return true
+		}
+	}
+	return false
 }
 
 // organizeDigestArticles organizes articles for a balanced digest
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/edition"
+	"nwcli/pkg/news"
+
+	"github.com/spf13/cobra"
+)
+
+var editionCmd = &cobra.Command{
+	Use:   "edition",
+	Short: "🗞️  Build offline news editions",
+	Long: `Build static news sites from the article cache.
+
+An edition is a directory with a front page, one page per article with
+its full text, local copies of article images and a search box. It needs
+no web server: open index.html in any browser, or copy the directory to
+a phone or e-reader.`,
+}
+
+var editionBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "🏗️  Build today's edition",
+	Long: `Build an edition from cached articles.
+
+Without --out the edition is archived as ~/.nwcli/editions/YYYY-MM-DD,
+and ~/.nwcli/editions/index.html lists all archived editions by date.
+Full article text and images are downloaded unless --offline is set;
+articles whose text cannot be loaded keep their feed content.`,
+	Example: `  nwcli latest && nwcli edition build
+  nwcli edition build --out ./today --country uk --limit 20`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+		country, _ := cmd.Flags().GetString("country")
+		limit, _ := cmd.Flags().GetInt("limit")
+		since, _ := cmd.Flags().GetDuration("since")
+		noImages, _ := cmd.Flags().GetBool("no-images")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		newsService, err := newNewsService(cmd, country, false)
+		if err != nil {
+			return err
+		}
+
+		var articles []news.Article
+		for _, article := range newsService.CachedNews(0) {
+			if since > 0 && article.Published.Before(time.Now().Add(-since)) {
+				continue
+			}
+			articles = append(articles, article)
+		}
+		if len(articles) == 0 {
+			return fmt.Errorf("no cached %s articles to build an edition from; run 'nwcli latest --country %s' first", country, country)
+		}
+		if limit > 0 && len(articles) > limit {
+			articles = articles[:limit]
+		}
+
+		date := time.Now()
+		archive := filepath.Join(config.Dir(), "editions")
+		archived := out == ""
+		if archived {
+			out = filepath.Join(archive, date.Format(edition.DateLayout))
+		}
+
+		opts := edition.Options{
+			Dir:      out,
+			Title:    fmt.Sprintf("NWCLI %s Edition", strings.ToUpper(country)),
+			Language: newsService.GetSources()[0].Language,
+			Date:     date,
+			Articles: articles,
+			Images:   !noImages,
+			Archived: archived,
+		}
+		if !newsService.IsOffline() {
+			opts.Fetcher = newsService
+		}
+		if verbose {
+			opts.Progress = func(done, total int) {
+				fmt.Fprintf(os.Stderr, "\r📥 Preparing articles %d/%d", done, total)
+				if done == total {
+					fmt.Fprintln(os.Stderr)
+				}
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		result, err := edition.Build(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if archived {
+			if err := edition.WriteArchive(archive, "NWCLI Editions"); err != nil {
+				return err
+			}
+		}
+
+		if verbose {
+			for _, failure := range result.Failures {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", failure)
+			}
+		}
+		fmt.Printf("✅ Built edition with %d articles (%d full text, %d images)\n",
+			result.Articles, result.FullText, result.Images)
+		fmt.Printf("   Open %s\n", filepath.Join(out, "index.html"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(editionCmd)
+	editionCmd.AddCommand(editionBuildCmd)
+
+	editionBuildCmd.Flags().StringP("out", "o", "", "output directory (default ~/.nwcli/editions/<date>)")
+	editionBuildCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
+	editionBuildCmd.Flags().IntP("limit", "l", 30, "number of articles in the edition")
+	editionBuildCmd.Flags().DurationP("since", "", 24*time.Hour, "only include articles published within this period (0 for all)")
+	editionBuildCmd.Flags().BoolP("no-images", "", false, "do not download article images")
+}
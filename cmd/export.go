@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"nwcli/pkg/epub"
+	"nwcli/pkg/export"
+	"nwcli/pkg/news"
+	"nwcli/pkg/offline"
+
+	"github.com/spf13/cobra"
+)
+
+// formatEPUB is the --format of digests written as EPUB books. EPUB files
+// are binary, so unlike the renderer formats they are written to a file.
+const formatEPUB = "epub"
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "📤 Export cached articles",
+	Long: `Export cached articles as CSV, TSV or NDJSON for analysis in tools
+such as pandas or DuckDB, or as an EPUB book with 'export epub'.
+
+Articles are streamed from the cache one at a time, in cache order, so
+large caches are never loaded into memory. Dates are RFC 3339. Fields
+with several values, such as categories and authors, are joined with
+"; " in CSV and TSV and are arrays in NDJSON.
+
+--since and --until take a duration before now (such as 24h), a date
+(2024-05-01) or an RFC 3339 time.`,
+	Example: `  nwcli export > articles.csv
+  nwcli export --format ndjson --fields title,source,published,link,categories
+  nwcli export -f tsv --country uk --since 48h --query election -o uk.tsv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := "csv"
+		if cmd.Flags().Changed("format") {
+			format, _ = cmd.Flags().GetString("format")
+		}
+		fieldList, _ := cmd.Flags().GetString("fields")
+		country, _ := cmd.Flags().GetString("country")
+		sources, _ := cmd.Flags().GetStringSlice("source")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		query, _ := cmd.Flags().GetString("query")
+		limit, _ := cmd.Flags().GetInt("limit")
+		output, _ := cmd.Flags().GetString("output")
+
+		fields, err := export.ParseFields(fieldList)
+		if err != nil {
+			return err
+		}
+		since, err := parseTimeFlag("since", sinceFlag)
+		if err != nil {
+			return err
+		}
+		until, err := parseTimeFlag("until", untilFlag)
+		if err != nil {
+			return err
+		}
+		if country != "" && !slices.Contains(news.GetAvailableCountries(), strings.ToLower(country)) {
+			return fmt.Errorf("unknown country %q (available: %s)", country, strings.Join(news.GetAvailableCountries(), ", "))
+		}
+
+		out := os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", output, err)
+			}
+			defer f.Close()
+			out = f
+		}
+		buf := bufio.NewWriter(out)
+
+		w, err := export.NewWriter(buf, format, fields)
+		if err != nil {
+			return err
+		}
+
+		countries := make(map[string]string)
+		count := 0
+		err = news.EachCachedArticle(func(article news.Article) error {
+			if limit > 0 && count >= limit {
+				return errExportDone
+			}
+			if country != "" {
+				if _, ok := countries[article.Source]; !ok {
+					countries[article.Source] = news.CountryForSource(article.Source)
+				}
+				if !strings.EqualFold(countries[article.Source], country) {
+					return nil
+				}
+			}
+			if len(sources) > 0 && !slices.ContainsFunc(sources, func(source string) bool {
+				return strings.EqualFold(source, article.Source)
+			}) {
+				return nil
+			}
+			if !since.IsZero() && article.Published.Before(since) {
+				return nil
+			}
+			if !until.IsZero() && article.Published.After(until) {
+				return nil
+			}
+			if query != "" && !article.Matches(query) {
+				return nil
+			}
+
+			count++
+			return w.Write(article)
+		})
+		if err != nil && !errors.Is(err, errExportDone) {
+			return err
+		}
+
+		if err := w.Close(); err != nil {
+			return err
+		}
+		if err := buf.Flush(); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		if output != "" {
+			fmt.Fprintf(os.Stderr, "✅ Exported %d articles to %s\n", count, output)
+		}
+		return nil
+	},
+}
+
+// errExportDone stops reading the cache once the export limit is reached
+var errExportDone = errors.New("export limit reached")
+
+var exportEPUBCmd = &cobra.Command{
+	Use:   "epub",
+	Short: "📚 Export cached articles as an EPUB book",
+	Long: `Write recent cached articles as an EPUB 3 book for e-readers.
+
+The book has a cover with the date and country, a table of contents
+grouped by source or category, and one chapter per article with its full
+text and lead image. Full text and images are downloaded unless --offline
+is set.`,
+	Example: `  nwcli export epub --since 24h
+  nwcli export epub --country uk --group category -o uk.epub`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		country, _ := cmd.Flags().GetString("country")
+		since, _ := cmd.Flags().GetDuration("since")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		newsService, err := newNewsService(cmd, country, false)
+		if err != nil {
+			return err
+		}
+
+		var articles []news.Article
+		for _, article := range newsService.CachedNews(0) {
+			if since > 0 && article.Published.Before(time.Now().Add(-since)) {
+				continue
+			}
+			articles = append(articles, article)
+		}
+		if len(articles) == 0 {
+			return fmt.Errorf("no cached %s articles to export; run 'nwcli latest --country %s' first", country, country)
+		}
+		if limit > 0 && len(articles) > limit {
+			articles = articles[:limit]
+		}
+
+		return writeEPUB(cmd, newsService, country, "NWCLI News", "news", articles)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportEPUBCmd)
+
+	exportCmd.Flags().String("fields", strings.Join(export.DefaultFields, ","),
+		fmt.Sprintf("comma-separated columns (%s)", strings.Join(export.FieldNames(), ", ")))
+	exportCmd.Flags().String("country", "", "only export articles from this country's sources")
+	exportCmd.Flags().StringSliceP("source", "s", []string{}, "only export articles from these sources")
+	exportCmd.Flags().String("since", "", "only export articles published after this time or duration ago")
+	exportCmd.Flags().String("until", "", "only export articles published before this time or duration ago")
+	exportCmd.Flags().StringP("query", "q", "", "only export articles matching this search query")
+	exportCmd.Flags().IntP("limit", "l", 0, "maximum number of articles (0 for all)")
+	exportCmd.Flags().StringP("output", "o", "", "file to write instead of standard output")
+
+	exportEPUBCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
+	exportEPUBCmd.Flags().DurationP("since", "", 24*time.Hour, "only include articles published within this period (0 for all)")
+	exportEPUBCmd.Flags().IntP("limit", "l", 50, "maximum number of articles")
+	exportEPUBCmd.Flags().StringP("output", "o", "", "EPUB file to write (default news-YYYY-MM-DD.epub)")
+	addEPUBFlags(exportEPUBCmd)
+}
+
+// addEPUBFlags adds the flags of EPUB output to cmd, except --output
+func addEPUBFlags(cmd *cobra.Command) {
+	cmd.Flags().String("group", epub.GroupSource, "group the table of contents by source or category")
+	cmd.Flags().Bool("no-images", false, "do not embed article images")
+}
+
+// writeEPUB loads the full text and images of articles and writes them
+// as an EPUB book, to <name>-<date>.epub unless --output is set
+func writeEPUB(cmd *cobra.Command, newsService *news.NewsService, country, title, name string, articles []news.Article) error {
+	output, _ := cmd.Flags().GetString("output")
+	group, _ := cmd.Flags().GetString("group")
+	noImages, _ := cmd.Flags().GetBool("no-images")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	if group != epub.GroupSource && group != epub.GroupCategory {
+		return fmt.Errorf("unknown group %q (available: %s, %s)", group, epub.GroupSource, epub.GroupCategory)
+	}
+
+	date := time.Now()
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.epub", name, date.Format("2006-01-02"))
+	}
+
+	opts := offline.Options{Images: !noImages}
+	if !newsService.IsOffline() {
+		opts.Fetcher = newsService
+	}
+	if verbose {
+		opts.Progress = func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\r📥 Loading articles %d/%d", done, total)
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	items := offline.Load(ctx, articles, opts)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if verbose {
+		for _, item := range items {
+			if item.Failure != "" {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", item.Failure)
+			}
+		}
+	}
+
+	sources := newsService.GetSources()
+	languages := make(map[string]string, len(sources))
+	for _, source := range sources {
+		languages[source.Name] = source.Language
+	}
+
+	book := epub.Book{
+		Title:           title,
+		Country:         countryName(country),
+		Date:            date,
+		Language:        sources[0].Language,
+		SourceLanguages: languages,
+		Group:           group,
+		Items:           items,
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	if err := epub.Write(f, book); err != nil {
+		f.Close()
+		os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	fmt.Printf("✅ Wrote %d articles to %s\n", len(items), output)
+	return nil
+}
+
+// parseTimeFlag parses a --since or --until value: a duration before
+// now, a date or an RFC 3339 time. An empty value is the zero time.
+func parseTimeFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s %q (use a duration like 24h, a date like 2024-05-01 or an RFC 3339 time)", name, value)
+}
+
+// countryName returns the name of a country code, or the code itself
+// when it is unknown
+func countryName(code string) string {
+	for _, country := range news.GetCountries() {
+		if strings.EqualFold(country.Code, code) {
+			return country.Name
+		}
+	}
+	return strings.ToUpper(code)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"nwcli/pkg/news"
+	"nwcli/pkg/tui"
 
 	"github.com/spf13/cobra"
 )
@@ -28,11 +29,23 @@ source information, and timestamps.`,
 		limit, _ := cmd.Flags().GetInt("limit")
 		source, _ := cmd.Flags().GetString("source")
 		category, _ := cmd.Flags().GetString("category")
-		format, _ := cmd.Flags().GetString("format")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		country, _ := cmd.Flags().GetString("country")
 		fullContent, _ := cmd.Flags().GetBool("full")
 		noPager, _ := cmd.Flags().GetBool("no-pager")
+		format, err := formatFlag(cmd)
+		if err != nil {
+			return err
+		}
+		layout, err := layoutFlag(cmd)
+		if err != nil {
+			return err
+		}
+		mediaType, _ := cmd.Flags().GetString("media")
+
+		if err := validateMediaType(mediaType); err != nil {
+			return err
+		}
 
 		if verbose {
 			fmt.Printf("🔄 Fetching latest news from %s", country)
@@ -43,40 +56,66 @@ source information, and timestamps.`,
 		}
 
 		// Create news service with options
-		newsService := news.NewNewsServiceWithOptions(country, fullContent)
+		newsService, err := newNewsService(cmd, country, fullContent)
+		if err != nil {
+			return err
+		}
+
+		title := fmt.Sprintf("Latest News (%s)", strings.ToUpper(country))
+		if fullContent {
+			title += " - Full Articles"
+		}
+
+		// Interactive reader: start from the cache and fetch in the background
+		if format == "markdown" && layout == layoutList && tui.ShouldUsePager(noPager) && !newsService.IsOffline() {
+			autoRefresh, _ := cmd.Flags().GetDuration("auto-refresh")
+			filter := func(article news.Article) bool {
+				return (source == "" || strings.EqualFold(article.Source, source)) &&
+					(category == "" || article.HasCategory(category)) &&
+					(mediaType == "" || article.HasMedia(mediaType))
+			}
+			return launchLiveReader(cmd, newsService, country, title, filter, limit, autoRefresh)
+		}
 
 		var articles []news.Article
-		var err error
+
+		// Media filtering happens after fetching, so fetch everything first
+		fetchLimit := limit
+		if mediaType != "" {
+			fetchLimit = 0
+		}
 
 		if source != "" || category != "" {
 			// Use filtering if source or category specified
-			articles, err = newsService.FilterArticles(source, category, time.Time{}, limit)
+			articles, err = newsService.FilterArticles(source, category, time.Time{}, fetchLimit)
 		} else {
 			// Get latest news
-			articles, err = newsService.GetLatestNews(limit)
+			articles, err = newsService.GetLatestNews(fetchLimit)
 		}
 
 		if err != nil {
 			return fmt.Errorf("failed to fetch news: %w", err)
 		}
 
+		if mediaType != "" {
+			articles = news.FilterByMedia(articles, mediaType)
+			if limit > 0 && len(articles) > limit {
+				articles = articles[:limit]
+			}
+		}
+
 		if verbose {
 			fmt.Printf("✅ Found %d articles\n\n", len(articles))
 		}
 
 		// Render based on format
-		switch format {
-		case "json":
-			return renderJSON(articles)
-		case "plain":
-			return renderPlain(articles)
-		default: // markdown
-			title := fmt.Sprintf("Latest News (%s)", strings.ToUpper(country))
-			if fullContent {
-				title += " - Full Articles"
-			}
-			return renderMarkdownWithPager(articles, title, noPager)
+		if format != "markdown" {
+			return renderArticles(format, articles, title)
+		}
+		if layout == layoutNewspaper {
+			return renderNewspaper(articles, title)
 		}
+		return renderMarkdownWithPager(articles, title, noPager, newsService)
 	},
 }
 
@@ -90,4 +129,7 @@ func init() {
 	latestCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
 	latestCmd.Flags().BoolP("full", "", false, "fetch full article content instead of summaries")
 	latestCmd.Flags().BoolP("no-pager", "", false, "disable interactive pager and output to stdout")
+	latestCmd.Flags().Duration("auto-refresh", 0, "refresh the interactive reader at this interval (e.g. 5m)")
+	latestCmd.Flags().StringP("media", "m", "", "only show articles with media of this type (audio, video)")
+	latestCmd.Flags().Int("summary", 0, "add an N-sentence extractive summary to each article (default summary.sentences from the config file)")
 }
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"mime"
+	"os"
+	"os/signal"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/mail"
+	"nwcli/pkg/news"
+	"nwcli/pkg/offline"
+	"nwcli/pkg/renderer"
+
+	"github.com/spf13/cobra"
+)
+
+// mailTimeout bounds sending a digest
+const mailTimeout = 2 * time.Minute
+
+// subjectData is the data of the mail.subject template
+type subjectData struct {
+	Title   string
+	Country string
+	Date    string
+	Count   int
+}
+
+// mailDigest sends articles as a multipart email with an HTML body and a
+// plain text alternative, or writes the message to an .eml file with
+// --dry-run
+func mailDigest(cmd *cobra.Command, newsService *news.NewsService, country, title string, articles []news.Article) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	output, _ := cmd.Flags().GetString("output")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	mc := cfg.Mail
+
+	to := mc.To
+	if cmd.Flags().Changed("to") {
+		to, _ = cmd.Flags().GetStringSlice("to")
+	}
+
+	subject, err := mailSubject(mc.Subject, subjectData{
+		Title:   title,
+		Country: countryName(country),
+		Date:    time.Now().Format("Monday, January 2, 2006"),
+		Count:   len(articles),
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var images []mail.Image
+	htmlRenderer := renderer.HTMLRenderer{}
+	if mc.InlineImages && !newsService.IsOffline() {
+		images, htmlRenderer.ImageSources = inlineImages(ctx, newsService, articles)
+		if verbose {
+			fmt.Fprintf(os.Stderr, "🖼️  Embedded %d images\n", len(images))
+		}
+	}
+
+	html, err := htmlRenderer.RenderArticles(articles, title)
+	if err != nil {
+		return fmt.Errorf("failed to render html output: %w", err)
+	}
+	text, err := renderer.PlainRenderer{}.RenderArticles(articles, title)
+	if err != nil {
+		return fmt.Errorf("failed to render plain output: %w", err)
+	}
+
+	msg := mail.Message{
+		From:    mc.From,
+		To:      to,
+		Subject: subject,
+		HTML:    html,
+		Text:    title + "\n\n" + text,
+		Images:  images,
+	}
+
+	if dryRun {
+		if output == "" {
+			output = fmt.Sprintf("digest-%s.eml", time.Now().Format("2006-01-02"))
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", output, err)
+		}
+		if _, err := msg.WriteTo(f); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		fmt.Printf("✅ Wrote the digest email to %s\n", output)
+		return nil
+	}
+
+	password := mc.Password
+	if env := os.Getenv("NWCLI_SMTP_PASSWORD"); env != "" {
+		password = env
+	}
+	opts := mail.Options{
+		Host:     mc.Host,
+		Port:     mc.Port,
+		Security: mc.Security,
+		Auth:     mc.Auth,
+		Username: mc.Username,
+		Password: password,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mailTimeout)
+	defer cancel()
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "📤 Sending to %s via %s...\n", strings.Join(to, ", "), mc.Host)
+	}
+	if err := mail.Send(ctx, opts, msg); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Sent the digest to %s\n", strings.Join(to, ", "))
+	return nil
+}
+
+// mailSubject renders the subject template, which defaults to the title
+func mailSubject(tmpl string, data subjectData) (string, error) {
+	if tmpl == "" {
+		return data.Title, nil
+	}
+
+	t, err := texttemplate.New("subject").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid mail subject template: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid mail subject template: %w", err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// inlineImages downloads the images of articles for embedding in an
+// email. It returns the images and their cid: URLs by article key.
+// Articles whose image cannot be loaded keep linking to it.
+func inlineImages(ctx context.Context, newsService *news.NewsService, articles []news.Article) ([]mail.Image, map[string]template.URL) {
+	items := offline.Load(ctx, articles, offline.Options{Fetcher: imageFetcher{newsService}, Images: true})
+
+	var images []mail.Image
+	sources := make(map[string]template.URL)
+	for i, item := range items {
+		contentType := mime.TypeByExtension(item.ImageExt)
+		if item.Image == nil || !strings.HasPrefix(contentType, "image/") {
+			continue
+		}
+
+		id := fmt.Sprintf("image%03d@nwcli", i+1)
+		images = append(images, mail.Image{
+			ContentID: id,
+			Type:      contentType,
+			Filename:  fmt.Sprintf("image%03d%s", i+1, item.ImageExt),
+			Data:      item.Image,
+		})
+		sources[item.Article.Key()] = template.URL("cid:" + id)
+	}
+	return images, sources
+}
+
+// imageFetcher loads images only, leaving articles as they are
+type imageFetcher struct {
+	*news.NewsService
+}
+
+func (imageFetcher) FetchFullContent(ctx context.Context, article news.Article) (news.Article, error) {
+	return article, nil
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+
+	"github.com/spf13/cobra"
+)
+
+var mediaCmd = &cobra.Command{
+	Use:   "media",
+	Short: "🎧 Podcasts and video bulletins",
+	Long: `Work with audio and video attached to news articles.
+
+Sources such as NPR News Now, NOS Journaal and France 24 publish
+podcasts and video bulletins as feed enclosures. Use 'media list'
+to find them and 'media download' to save them for offline use.`,
+}
+
+var mediaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "📋 List articles with audio or video",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		country, _ := cmd.Flags().GetString("country")
+		mediaType, _ := cmd.Flags().GetString("media")
+		format, err := formatFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := validateMediaType(mediaType); err != nil {
+			return err
+		}
+
+		newsService, err := newNewsService(cmd, country, false)
+		if err != nil {
+			return err
+		}
+
+		articles, err := newsService.GetLatestNews(0)
+		if err != nil {
+			return fmt.Errorf("failed to fetch news: %w", err)
+		}
+
+		articles = news.FilterByMedia(articles, mediaType)
+		if limit > 0 && len(articles) > limit {
+			articles = articles[:limit]
+		}
+
+		if format != "markdown" {
+			return renderArticles(format, articles, "Articles with media")
+		}
+
+		if len(articles) == 0 {
+			fmt.Println("📭 No articles with media found")
+			return nil
+		}
+
+		for _, article := range articles {
+			for _, media := range article.Media {
+				details := []string{media.Type}
+				if duration := media.DurationString(); duration != "" {
+					details = append(details, duration)
+				}
+				if media.Size > 0 {
+					details = append(details, formatBytes(media.Size))
+				}
+				fmt.Printf("%s  %s %s — %s (%s)\n",
+					article.ID(), mediaBadge(media.Type), article.Title, article.Source,
+					strings.Join(details, ", "))
+			}
+		}
+
+		return nil
+	},
+}
+
+var mediaDownloadCmd = &cobra.Command{
+	Use:   "download <id>",
+	Short: "⬇️  Download an article's audio or video",
+	Long: `Download the media attached to a cached article.
+
+The article ID is shown by 'nwcli media list'; a unique prefix is enough.
+Interrupted downloads are kept as a .part file and resumed on the next run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		index, _ := cmd.Flags().GetInt("index")
+
+		newsService, err := newNewsService(cmd, "nl", false)
+		if err != nil {
+			return err
+		}
+
+		article, err := newsService.FindArticle(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(article.Media) == 0 {
+			return fmt.Errorf("article %s has no audio or video", article.ID())
+		}
+		if index < 0 || index >= len(article.Media) {
+			return fmt.Errorf("media index %d out of range (article has %d)", index, len(article.Media))
+		}
+		media := article.Media[index]
+
+		dest := output
+		if info, err := os.Stat(output); err == nil && info.IsDir() {
+			dest = filepath.Join(output, media.Filename())
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Fprintf(os.Stderr, "%s %s\n", mediaBadge(media.Type), article.Title)
+		bar := newProgressBar(os.Stderr)
+		err = newsService.DownloadMedia(ctx, article, media, dest, bar.Update)
+		bar.Finish()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Saved to %s\n", dest)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mediaCmd)
+	mediaCmd.AddCommand(mediaListCmd)
+	mediaCmd.AddCommand(mediaDownloadCmd)
+
+	mediaListCmd.Flags().IntP("limit", "l", 20, "number of articles to show")
+	mediaListCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
+	mediaListCmd.Flags().StringP("media", "m", "", "only show media of this type (audio, video)")
+
+	mediaDownloadCmd.Flags().StringP("output", "o", ".", "output file or directory")
+	mediaDownloadCmd.Flags().IntP("index", "i", 0, "which media item to download when an article has several")
+}
+
+// validateMediaType checks the value of a --media flag
+func validateMediaType(mediaType string) error {
+	switch mediaType {
+	case "", news.MediaAudio, news.MediaVideo:
+		return nil
+	default:
+		return fmt.Errorf("invalid media type %q (use audio or video)", mediaType)
+	}
+}
+
+// mediaBadge returns an icon for a media type
+func mediaBadge(mediaType string) string {
+	if mediaType == news.MediaVideo {
+		return "🎬"
+	}
+	return "🎧"
+}
+
+// formatBytes formats a byte count for display
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// progressBar draws a single-line download progress bar
+type progressBar struct {
+	out      *os.File
+	last     time.Time
+	done     int64
+	total    int64
+	started  bool
+	finished bool
+}
+
+func newProgressBar(out *os.File) *progressBar {
+	return &progressBar{out: out}
+}
+
+// Update redraws the bar, at most ten times per second
+func (pb *progressBar) Update(done, total int64) {
+	pb.done, pb.total = done, total
+	if pb.started && time.Since(pb.last) < 100*time.Millisecond && done != total {
+		return
+	}
+	pb.started = true
+	pb.last = time.Now()
+	pb.draw()
+}
+
+// Finish draws the final state and ends the line
+func (pb *progressBar) Finish() {
+	if !pb.started || pb.finished {
+		return
+	}
+	pb.finished = true
+	pb.draw()
+	fmt.Fprintln(pb.out)
+}
+
+func (pb *progressBar) draw() {
+	const width = 30
+
+	if pb.total <= 0 {
+		fmt.Fprintf(pb.out, "\r⬇️  %s", formatBytes(pb.done))
+		return
+	}
+
+	ratio := float64(pb.done) / float64(pb.total)
+	if ratio > 1 {
+		ratio = 1
+	}
+	filled := int(ratio * width)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	fmt.Fprintf(pb.out, "\r⬇️  %s %3.0f%% %s / %s", bar, ratio*100,
+		formatBytes(pb.done), formatBytes(pb.total))
+}
@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/renderer"
+
 	"github.com/spf13/cobra"
 )
 
@@ -28,8 +34,21 @@ func Execute() error {
 
 func init() {
 	cobra.OnInitialize()
+	// Let sources served stale finish refetching before exiting, within
+	// the fetch timeout
+	cobra.OnFinalize(news.WaitForRevalidation)
 
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().StringP("format", "f", "markdown", "output format (markdown, json, plain)")
+	rootCmd.PersistentFlags().StringP("format", "f", "markdown",
+		fmt.Sprintf("output format (%s)", strings.Join(renderer.Formats(), ", ")))
+	rootCmd.PersistentFlags().String("layout", "list", "markdown layout: list, or newspaper for a multi-column front page")
+
+	// Network flags (override ~/.nwcli/config.json)
+	rootCmd.PersistentFlags().String("user-agent", "", "User-Agent header sent to news sources")
+	rootCmd.PersistentFlags().String("proxy", "", "proxy URL (http://, https:// or socks5://)")
+	rootCmd.PersistentFlags().StringSlice("ca-cert", []string{}, "extra PEM root certificate files to trust")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "per-request timeout (e.g. 15s)")
+	rootCmd.PersistentFlags().Bool("offline", false, "never touch the network, serve everything from the cache")
+	rootCmd.PersistentFlags().Bool("refresh", false, "fetch every source now instead of serving fresh ones from the cache")
 }
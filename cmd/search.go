@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"nwcli/pkg/news"
+	"nwcli/pkg/renderer"
+	"nwcli/pkg/tui"
 
 	"github.com/spf13/cobra"
 )
@@ -15,8 +17,11 @@ var searchCmd = &cobra.Command{
 	Long: `Search through Dutch news articles using keywords.
 	
 The search looks through article titles, descriptions, and content
-to find relevant matches. Results are ranked by relevance and
-displayed in a beautiful format.
+to find relevant matches. Each result shows where it matched: the
+matched fields and snippets of text around the matches, with the
+query highlighted. JSON output adds a "matches" array with the
+matched fields, the character positions of every match and the
+snippets.
 
 Examples:
   nwcli search "climate change"
@@ -27,11 +32,23 @@ Examples:
 		// Get flags
 		limit, _ := cmd.Flags().GetInt("limit")
 		source, _ := cmd.Flags().GetString("source")
-		format, _ := cmd.Flags().GetString("format")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		country, _ := cmd.Flags().GetString("country")
 		fullContent, _ := cmd.Flags().GetBool("full")
 		noPager, _ := cmd.Flags().GetBool("no-pager")
+		format, err := formatFlag(cmd)
+		if err != nil {
+			return err
+		}
+		layout, err := layoutFlag(cmd)
+		if err != nil {
+			return err
+		}
+		mediaType, _ := cmd.Flags().GetString("media")
+
+		if err := validateMediaType(mediaType); err != nil {
+			return err
+		}
 
 		// Combine all args into search query
 		query := strings.Join(args, " ")
@@ -45,23 +62,49 @@ Examples:
 		}
 
 		// Create news service with options
-		newsService := news.NewNewsServiceWithOptions(country, fullContent)
+		newsService, err := newNewsService(cmd, country, fullContent)
+		if err != nil {
+			return err
+		}
+
+		title := fmt.Sprintf("Search Results for '%s' (%s)", query, strings.ToUpper(country))
+		if fullContent {
+			title += " - Full Articles"
+		}
 
-		// Search articles
-		articles, err := newsService.SearchArticles(query, limit)
+		// Interactive reader: start from the cached matches and fetch in
+		// the background
+		if format == "markdown" && layout == layoutList && tui.ShouldUsePager(noPager) {
+			filter := func(article news.Article) bool {
+				return article.Matches(query) &&
+					(source == "" || strings.EqualFold(article.Source, source)) &&
+					(mediaType == "" || article.HasMedia(mediaType))
+			}
+			articles := cachedArticles(newsService, filter, limit)
+			if len(articles) > 0 || !newsService.IsOffline() {
+				return tui.LaunchLiveTUI(articles, title, tui.LiveOptions{
+					Fetcher:   liveFetcher(newsService),
+					Filter:    filter,
+					Limit:     limit,
+					Content:   contentFetcher(newsService),
+					Highlight: query,
+				})
+			}
+		}
+
+		// Search articles, narrowing them down before applying the limit
+		searchLimit := limit
+		if source != "" || mediaType != "" {
+			searchLimit = 0
+		}
+		articles, err := newsService.SearchArticles(query, searchLimit)
 		if err != nil {
 			return fmt.Errorf("failed to search articles: %w", err)
 		}
 
-		// Filter by source if specified
-		if source != "" {
-			var filtered []news.Article
-			for _, article := range articles {
-				if strings.EqualFold(article.Source, source) {
-					filtered = append(filtered, article)
-				}
-			}
-			articles = filtered
+		articles = filterSearchResults(articles, source, mediaType)
+		if limit > 0 && len(articles) > limit {
+			articles = articles[:limit]
 		}
 
 		if verbose {
@@ -69,21 +112,35 @@ Examples:
 		}
 
 		// Render based on format
-		switch format {
-		case "json":
-			return renderJSON(articles)
-		case "plain":
-			return renderPlain(articles)
-		default: // markdown
-			title := fmt.Sprintf("Search Results for '%s' (%s)", query, strings.ToUpper(country))
-			if fullContent {
-				title += " - Full Articles"
-			}
-			return renderMarkdownWithPager(articles, title, noPager)
+		if layout == layoutNewspaper && format == "markdown" {
+			return renderNewspaper(articles, title)
 		}
+		results := news.NewSearchResults(articles, query)
+		return renderOutput(format, func(r renderer.Renderer) (string, error) {
+			return r.RenderSearchResults(results, title)
+		})
 	},
 }
 
+// filterSearchResults keeps the articles from source with media of
+// mediaType; empty values keep everything
+func filterSearchResults(articles []news.Article, source, mediaType string) []news.Article {
+	if source != "" {
+		var filtered []news.Article
+		for _, article := range articles {
+			if strings.EqualFold(article.Source, source) {
+				filtered = append(filtered, article)
+			}
+		}
+		articles = filtered
+	}
+
+	if mediaType != "" {
+		articles = news.FilterByMedia(articles, mediaType)
+	}
+	return articles
+}
+
 func init() {
 	rootCmd.AddCommand(searchCmd)
 
@@ -93,4 +150,6 @@ func init() {
 	searchCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
 	searchCmd.Flags().BoolP("full", "", false, "search in full article content instead of summaries")
 	searchCmd.Flags().BoolP("no-pager", "", false, "disable interactive pager and output to stdout")
+	searchCmd.Flags().StringP("media", "m", "", "only show results with media of this type (audio, video)")
+	searchCmd.Flags().Int("summary", 0, "add an N-sentence extractive summary to each article (default summary.sentences from the config file)")
 }
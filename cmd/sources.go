@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"nwcli/pkg/news"
 	"nwcli/pkg/renderer"
@@ -22,7 +21,10 @@ and RSS feed URL.
 Use this information to filter news by specific sources using the
 --source flag in other commands.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		format, _ := cmd.Flags().GetString("format")
+		format, err := formatFlag(cmd)
+		if err != nil {
+			return err
+		}
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		country, _ := cmd.Flags().GetString("country")
 
@@ -38,17 +40,9 @@ Use this information to filter news by specific sources using the
 			fmt.Printf("✅ Found %d sources\n\n", len(sources))
 		}
 
-		// Render based on format
-		switch format {
-		case "json":
-			// TODO: Implement JSON output
-			fmt.Println("JSON output not yet implemented")
-			return nil
-		case "plain":
-			return renderSourcesPlain(sources)
-		default: // markdown
-			return renderSourcesMarkdown(sources)
-		}
+		return renderOutput(format, func(r renderer.Renderer) (string, error) {
+			return r.RenderSources(sources)
+		})
 	},
 }
 
@@ -58,58 +52,3 @@ func init() {
 	// Flags
 	sourcesCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
 }
-
-func renderSourcesMarkdown(sources []news.Source) error {
-	renderer, err := renderer.NewMarkdownRenderer()
-	if err != nil {
-		return fmt.Errorf("failed to create renderer: %w", err)
-	}
-
-	output, err := renderer.RenderSources(sources)
-	if err != nil {
-		return fmt.Errorf("failed to render sources: %w", err)
-	}
-
-	fmt.Print(output)
-	return nil
-}
-
-func renderSourcesPlain(sources []news.Source) error {
-	// Determine country name from sources
-	countryName := "News Sources"
-	if len(sources) > 0 {
-		switch sources[0].Language {
-		case "nl":
-			countryName = "Dutch News Sources"
-		case "en":
-			countryName = "English News Sources"
-		case "de":
-			countryName = "German News Sources"
-		case "fr":
-			countryName = "French News Sources"
-		}
-	}
-
-	fmt.Printf("Available %s:\n", countryName)
-	fmt.Println(strings.Repeat("=", len(countryName)+11))
-
-	// Group by category
-	categories := make(map[string][]news.Source)
-	for _, source := range sources {
-		categories[source.Category] = append(categories[source.Category], source)
-	}
-
-	for category, sources := range categories {
-		fmt.Printf("\n%s:\n", category)
-		fmt.Println(strings.Repeat("-", len(category)+1))
-
-		for _, source := range sources {
-			fmt.Printf("• %s\n", source.Name)
-			fmt.Printf("  %s\n", source.Description)
-			fmt.Printf("  URL: %s\n", source.URL)
-			fmt.Printf("  Language: %s\n\n", source.Language)
-		}
-	}
-
-	return nil
-}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/news"
+	"nwcli/pkg/renderer"
+	"nwcli/pkg/topics"
+	"nwcli/pkg/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var topicCmd = &cobra.Command{
+	Use:   "topic <name>",
+	Short: "📌 Show new matches of a saved search",
+	Long: `Run a search saved with 'nwcli topics save'. Only matches that were
+not shown before are listed, so running a topic every day shows what is
+new since the day before, including late items with an older date. Use
+--all to show every match and --peek to leave them unmarked.
+
+Sources past their cache TTL are fetched first, like with 'latest'. With
+--tui the reader opens on the cached matches at once and fetches those
+sources in the background; matches that arrive while it is open stay new
+for the next run.
+
+Examples:
+  nwcli topic climate
+  nwcli topic climate --all -f json
+  nwcli topic climate --tui`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		peek, _ := cmd.Flags().GetBool("peek")
+		openTUI, _ := cmd.Flags().GetBool("tui")
+		limit, _ := cmd.Flags().GetInt("limit")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		format, err := formatFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		topic, ok := cfg.Topic(args[0])
+		if !ok {
+			return fmt.Errorf("no topic named %q (see 'nwcli topics list')", args[0])
+		}
+
+		newsService, err := newNewsService(cmd, topicCountry(topic), false)
+		if err != nil {
+			return err
+		}
+
+		markers := topics.LoadMarkers()
+		marker := markers[topics.Key(topic.Name)]
+		title := fmt.Sprintf("Topic: %s (%q)", topic.Name, topic.Query)
+		if !all {
+			title = fmt.Sprintf("Topic: %s (%q) - New Matches", topic.Name, topic.Query)
+		}
+
+		// The reader starts from the cached matches and fetches in the
+		// background instead of waiting for the sources first
+		openTUI = openTUI && format == "markdown"
+		if !openTUI {
+			if verbose {
+				fmt.Printf("🔄 Updating %s sources for topic %q...\n", topicCountry(topic), topic.Name)
+			}
+			// Fetch the sources that are not fresh; failures are reported
+			// as warnings and the search falls back to the cache
+			newsService.GetLatestNews(0)
+		}
+
+		match := topicFilter(topic)
+		filter := match
+		if !all {
+			filter = func(article news.Article) bool {
+				return marker.IsNew(article) && match(article)
+			}
+		}
+		matches := topicMatches(newsService, topic)
+		articles := cachedArticles(newsService, filter, limit)
+
+		// Only the matches shown are marked as seen, so those cut off by
+		// --limit are still new next time
+		if !peek {
+			markers[topics.Key(topic.Name)] = marker.Advance(articles, matches)
+			if err := topics.SaveMarkers(markers); err != nil {
+				return err
+			}
+		}
+
+		if openTUI && (len(articles) > 0 || !newsService.IsOffline()) {
+			return tui.LaunchLiveTUI(articles, title, tui.LiveOptions{
+				Fetcher:   liveFetcher(newsService),
+				Filter:    filter,
+				Limit:     limit,
+				Content:   contentFetcher(newsService),
+				Highlight: topic.Query,
+			})
+		}
+
+		if len(articles) == 0 && format == "markdown" {
+			if all || marker.LastRun.IsZero() {
+				fmt.Printf("📭 No matches for topic %q\n", topic.Name)
+			} else {
+				fmt.Printf("✨ No new matches for topic %q since %s\n", topic.Name, marker.LastRun.Format("Jan 2 15:04"))
+				fmt.Println("   Use --all to show every match")
+			}
+			return nil
+		}
+
+		results := news.NewSearchResults(articles, topic.Query)
+		return renderOutput(format, func(r renderer.Renderer) (string, error) {
+			return r.RenderSearchResults(results, title)
+		})
+	},
+}
+
+var topicsCmd = &cobra.Command{
+	Use:   "topics",
+	Short: "📌 Manage saved searches",
+	Long:  `Save, list and remove the searches that 'nwcli topic' runs.`,
+}
+
+var topicsSaveCmd = &cobra.Command{
+	Use:   "save <name> <query>",
+	Short: "💾 Save a search",
+	Long: `Save a search under a name in ~/.nwcli/config.json, together with its
+country, source and media filters. Run it with 'nwcli topic <name>'.
+Saving under an existing name replaces that search.
+
+Examples:
+  nwcli topics save climate "climate change" --country uk
+  nwcli topics save ajax "Ajax" --source "NOS Sport"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		country, _ := cmd.Flags().GetString("country")
+		source, _ := cmd.Flags().GetString("source")
+		mediaType, _ := cmd.Flags().GetString("media")
+
+		if err := validateMediaType(mediaType); err != nil {
+			return err
+		}
+
+		topic := config.Topic{
+			Name:    strings.TrimSpace(args[0]),
+			Query:   strings.Join(args[1:], " "),
+			Country: strings.ToLower(country),
+			Source:  source,
+			Media:   mediaType,
+		}
+		if topic.Name == "" {
+			return fmt.Errorf("the topic name cannot be empty")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		replaced := cfg.SetTopic(topic)
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+
+		if replaced {
+			fmt.Printf("✅ Updated topic %q: %s\n", topic.Name, topic.Query)
+		} else {
+			fmt.Printf("✅ Saved topic %q: %s\n", topic.Name, topic.Query)
+		}
+		fmt.Printf("   Run it with 'nwcli topic %s'\n", topic.Name)
+		return nil
+	},
+}
+
+var topicsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "📋 List saved searches",
+	Long: `List the saved searches with their filters, when each was last run
+and how many of the cached articles are new matches. Nothing is fetched.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		if len(cfg.Topics) == 0 {
+			fmt.Println("📭 No saved searches")
+			fmt.Println(`   Save one with 'nwcli topics save <name> "<query>"'`)
+			return nil
+		}
+
+		markers := topics.LoadMarkers()
+		for _, topic := range cfg.Topics {
+			newsService, err := newNewsService(cmd, topicCountry(topic), false)
+			if err != nil {
+				return err
+			}
+			marker := markers[topics.Key(topic.Name)]
+			pending := len(marker.New(topicMatches(newsService, topic)))
+
+			fmt.Printf("📌 %s: %q\n", topic.Name, topic.Query)
+			fmt.Printf("   %s\n", topicFilters(topic))
+			if marker.LastRun.IsZero() {
+				fmt.Printf("   never run • %d cached matches\n", pending)
+			} else {
+				fmt.Printf("   last run %s • %d new\n", marker.LastRun.Format("Jan 2 15:04"), pending)
+			}
+		}
+		return nil
+	},
+}
+
+var topicsRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "🗑️  Remove a saved search",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if !cfg.RemoveTopic(args[0]) {
+			return fmt.Errorf("no topic named %q (see 'nwcli topics list')", args[0])
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+
+		markers := topics.LoadMarkers()
+		delete(markers, topics.Key(args[0]))
+		if err := topics.SaveMarkers(markers); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed topic %q\n", args[0])
+		return nil
+	},
+}
+
+// topicCountry returns the country a topic searches
+func topicCountry(topic config.Topic) string {
+	if topic.Country == "" {
+		return "nl"
+	}
+	return topic.Country
+}
+
+// topicFilter reports whether an article matches the query and filters
+// of a topic
+func topicFilter(topic config.Topic) func(news.Article) bool {
+	return func(article news.Article) bool {
+		return article.Matches(topic.Query) &&
+			(topic.Source == "" || strings.EqualFold(article.Source, topic.Source)) &&
+			(topic.Media == "" || article.HasMedia(topic.Media))
+	}
+}
+
+// topicMatches returns the cached articles of the topic's country that
+// match its query and filters, newest first
+func topicMatches(newsService *news.NewsService, topic config.Topic) []news.Article {
+	return cachedArticles(newsService, topicFilter(topic), 0)
+}
+
+// topicFilters describes the filters of a topic
+func topicFilters(topic config.Topic) string {
+	parts := []string{"country " + strings.ToUpper(topicCountry(topic))}
+	if topic.Source != "" {
+		parts = append(parts, "source "+topic.Source)
+	}
+	if topic.Media != "" {
+		parts = append(parts, topic.Media+" only")
+	}
+	return strings.Join(parts, " • ")
+}
+
+func init() {
+	rootCmd.AddCommand(topicCmd)
+	rootCmd.AddCommand(topicsCmd)
+	topicsCmd.AddCommand(topicsSaveCmd)
+	topicsCmd.AddCommand(topicsListCmd)
+	topicsCmd.AddCommand(topicsRemoveCmd)
+
+	topicCmd.Flags().Bool("all", false, "show every match, not only new ones")
+	topicCmd.Flags().Bool("peek", false, "show new matches without marking them as seen")
+	topicCmd.Flags().Bool("tui", false, "open the matches in the interactive reader")
+	topicCmd.Flags().IntP("limit", "l", 0, "number of matches to show, newest first (0 for all)")
+
+	topicsSaveCmd.Flags().StringP("country", "", "nl", "country code (nl, us, uk, de, fr)")
+	topicsSaveCmd.Flags().StringP("source", "s", "", "only match articles from this source")
+	topicsSaveCmd.Flags().StringP("media", "m", "", "only match articles with media of this type (audio, video)")
+}
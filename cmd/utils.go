@@ -1,64 +1,322 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"nwcli/pkg/config"
 	"nwcli/pkg/news"
 	"nwcli/pkg/renderer"
+	"nwcli/pkg/summary"
 	"nwcli/pkg/tui"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+// newNewsService creates a news service from the config file and global flags
+func newNewsService(cmd *cobra.Command, country string, fullContent bool) (*news.NewsService, error) {
+	opts, err := serviceOptions(cmd, country, fullContent)
+	if err != nil {
+		return nil, err
+	}
+
+	newsService, err := news.NewNewsServiceFromOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create news service: %w", err)
+	}
+
+	return newsService, nil
+}
+
+// serviceOptions builds news service options from the config file and
+// global flags
+func serviceOptions(cmd *cobra.Command, country string, fullContent bool) (news.ServiceOptions, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return news.ServiceOptions{}, err
+	}
+
+	timeout, err := cfg.HTTP.TimeoutDuration()
+	if err != nil {
+		return news.ServiceOptions{}, err
+	}
+
+	freshness, err := cacheFreshness(cmd, cfg.Cache)
+	if err != nil {
+		return news.ServiceOptions{}, err
+	}
+
+	opts := news.ServiceOptions{
+		Country:     country,
+		FullContent: fullContent,
+		Offline:     cfg.Offline,
+		Freshness:   freshness,
+		Fetcher: news.FetcherOptions{
+			UserAgent: cfg.HTTP.UserAgent,
+			Proxy:     cfg.HTTP.Proxy,
+			CACerts:   cfg.HTTP.CACerts,
+			Timeout:   timeout,
+			Headers:   cfg.HTTP.Headers,
+		},
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("user-agent") {
+		opts.Fetcher.UserAgent, _ = flags.GetString("user-agent")
+	}
+	if flags.Changed("proxy") {
+		opts.Fetcher.Proxy, _ = flags.GetString("proxy")
+	}
+	if flags.Changed("ca-cert") {
+		caCerts, _ := flags.GetStringSlice("ca-cert")
+		opts.Fetcher.CACerts = append(opts.Fetcher.CACerts, caCerts...)
+	}
+	if flags.Changed("timeout") {
+		opts.Fetcher.Timeout, _ = flags.GetDuration("timeout")
+	}
+	if flags.Changed("offline") {
+		opts.Offline, _ = flags.GetBool("offline")
+	}
+
+	sentences := cfg.Summary.Sentences
+	if flags.Changed("summary") {
+		sentences, _ = flags.GetInt("summary")
+	}
+	if sentences > 0 {
+		opts.Summarize = func(article news.Article) string {
+			return summary.SummarizeArticle(article, sentences)
+		}
+	}
+
+	return opts, nil
+}
+
+// cacheFreshness builds the cache TTLs from the config file and the
+// --refresh flag
+func cacheFreshness(cmd *cobra.Command, cc config.CacheConfig) (news.Freshness, error) {
+	freshness := news.Freshness{
+		StaleWhileRevalidate: news.DefaultStaleWhileRevalidate,
+		SourceTTL:            make(map[string]time.Duration),
+	}
+	freshness.Refresh, _ = cmd.Flags().GetBool("refresh")
+
+	var err error
+	if cc.TTL != "" {
+		if freshness.TTL, err = time.ParseDuration(cc.TTL); err != nil {
+			return freshness, fmt.Errorf("invalid cache ttl %q: %w", cc.TTL, err)
+		}
+	}
+	if cc.StaleWhileRevalidate != "" {
+		if freshness.StaleWhileRevalidate, err = time.ParseDuration(cc.StaleWhileRevalidate); err != nil {
+			return freshness, fmt.Errorf("invalid cache stale_while_revalidate %q: %w", cc.StaleWhileRevalidate, err)
+		}
+	}
+	for source, value := range cc.Sources {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return freshness, fmt.Errorf("invalid cache ttl %q for %s: %w", value, source, err)
+		}
+		freshness.SourceTTL[source] = ttl
+	}
+
+	return freshness, nil
+}
+
 // Helper functions for rendering that can be used across commands
 
 func renderMarkdown(articles []news.Article, title string) error {
-	return renderMarkdownWithPager(articles, title, false)
+	return renderMarkdownWithPager(articles, title, false, nil)
 }
 
-func renderMarkdownWithPager(articles []news.Article, title string, noPager bool) error {
+// renderMarkdownWithPager shows articles in the TUI, or prints them as
+// markdown when no pager is wanted. newsService, when given, lets the TUI
+// download full article text.
+func renderMarkdownWithPager(articles []news.Article, title string, noPager bool, newsService *news.NewsService) error {
 	// Check if we should use the TUI pager
 	if tui.ShouldUsePager(noPager) && len(articles) > 0 {
-		return tui.LaunchTUI(articles, title)
+		return tui.LaunchLiveTUI(articles, title, tui.LiveOptions{
+			Content: contentFetcher(newsService),
+		})
 	}
 
 	// Fallback to regular markdown rendering
-	renderer, err := renderer.NewMarkdownRenderer()
+	return renderArticles("markdown", articles, title)
+}
+
+// formatFlag returns the --format flag, failing with the available
+// formats when it is unknown
+func formatFlag(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if err := renderer.CheckFormat(format); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// renderOutput prints what render produces with the renderer of format
+func renderOutput(format string, render func(renderer.Renderer) (string, error)) error {
+	r, err := renderer.New(format)
 	if err != nil {
 		return fmt.Errorf("failed to create renderer: %w", err)
 	}
 
-	output, err := renderer.RenderArticles(articles, title)
+	output, err := render(r)
 	if err != nil {
-		return fmt.Errorf("failed to render articles: %w", err)
+		return fmt.Errorf("failed to render %s output: %w", format, err)
 	}
 
 	fmt.Print(output)
 	return nil
 }
 
-func renderJSON(articles []news.Article) error {
-	data, err := json.MarshalIndent(articles, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+// renderArticles prints articles in the given format
+func renderArticles(format string, articles []news.Article, title string) error {
+	return renderOutput(format, func(r renderer.Renderer) (string, error) {
+		return r.RenderArticles(articles, title)
+	})
+}
+
+// Markdown layouts
+const (
+	layoutList      = "list"
+	layoutNewspaper = "newspaper"
+)
+
+// layoutFlag returns the validated --layout flag
+func layoutFlag(cmd *cobra.Command) (string, error) {
+	layout, _ := cmd.Flags().GetString("layout")
+	switch layout {
+	case layoutList, layoutNewspaper:
+		return layout, nil
+	default:
+		return "", fmt.Errorf("unknown layout %q (available: %s, %s)", layout, layoutList, layoutNewspaper)
 	}
-	fmt.Println(string(data))
+}
+
+// renderNewspaper prints articles as a newspaper front page sized to the
+// terminal
+func renderNewspaper(articles []news.Article, title string) error {
+	fmt.Print(renderer.NewNewspaperRenderer(terminalWidth()).RenderArticles(articles, title))
 	return nil
 }
 
-func renderPlain(articles []news.Article) error {
-	for i, article := range articles {
-		if i > 0 {
-			fmt.Println("\n" + strings.Repeat("-", 50))
+// terminalWidth returns the width of the terminal on stdout, falling back
+// to $COLUMNS and then 100 columns when output is redirected
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 100
+}
+
+// launchLiveReader opens the TUI with cached articles matching filter and
+// refreshes them from the network in the background. Additional tabs are
+// loaded on demand from the country given.
+func launchLiveReader(cmd *cobra.Command, newsService *news.NewsService, country, title string, filter func(news.Article) bool, limit int, autoRefresh time.Duration) error {
+	return tui.LaunchLiveTUI(cachedArticles(newsService, filter, limit), title, tui.LiveOptions{
+		Fetcher:         newsService,
+		Filter:          filter,
+		Limit:           limit,
+		RefreshInterval: autoRefresh,
+		Tabs:            tabProvider(cmd, newsService, country, limit, autoRefresh),
+		Content:         contentFetcher(newsService),
+	})
+}
+
+// contentFetcher returns newsService as the TUI's full text source, or nil
+// when it is offline or missing
+func contentFetcher(newsService *news.NewsService) tui.ContentFetcher {
+	if newsService == nil || newsService.IsOffline() {
+		return nil
+	}
+	return newsService
+}
+
+// liveFetcher returns newsService as the TUI's background fetcher, or nil
+// when it is offline
+func liveFetcher(newsService *news.NewsService) tui.Fetcher {
+	if newsService.IsOffline() {
+		return nil
+	}
+	return newsService
+}
+
+// cachedArticles returns up to limit cached articles matching filter
+func cachedArticles(newsService *news.NewsService, filter func(news.Article) bool, limit int) []news.Article {
+	var articles []news.Article
+	for _, article := range newsService.CachedNews(0) {
+		if filter(article) {
+			articles = append(articles, article)
+		}
+	}
+	if limit > 0 && len(articles) > limit {
+		articles = articles[:limit]
+	}
+	newsService.Summarize(articles)
+	return articles
+}
+
+// tabProvider builds the articles and refresh options for a TUI tab.
+// Tabs without a country use defaultCountry; source tabs use the country
+// the source belongs to. Every tab shares the article cache of
+// newsService, so refreshing one tab keeps the articles of the others.
+func tabProvider(cmd *cobra.Command, newsService *news.NewsService, defaultCountry string, limit int, autoRefresh time.Duration) tui.TabProvider {
+	return func(tab config.Tab) ([]news.Article, tui.LiveOptions, error) {
+		country := tab.Country
+		if country == "" && tab.Kind == config.TabSource {
+			country = news.CountryForSource(tab.Value)
+		}
+		if tab.Kind == config.TabCountry {
+			country = tab.Value
+		}
+		if country == "" {
+			country = defaultCountry
+		}
+
+		var filter func(news.Article) bool
+		switch tab.Kind {
+		case config.TabCountry:
+			filter = func(news.Article) bool { return true }
+		case config.TabCategory:
+			filter = func(article news.Article) bool { return article.HasCategory(tab.Value) }
+		case config.TabSource:
+			filter = func(article news.Article) bool { return strings.EqualFold(article.Source, tab.Value) }
+		case config.TabQuery:
+			filter = func(article news.Article) bool { return article.Matches(tab.Value) }
+		default:
+			return nil, tui.LiveOptions{}, fmt.Errorf("unknown tab kind %q", tab.Kind)
 		}
 
-		fmt.Printf("Title: %s\n", article.Title)
-		fmt.Printf("Source: %s\n", article.Source)
-		fmt.Printf("Published: %s\n", article.Published.Format("2006-01-02 15:04"))
-		if article.Description != "" {
-			fmt.Printf("Description: %s\n", article.Description)
+		fullContent, _ := cmd.Flags().GetBool("full")
+		opts, err := serviceOptions(cmd, country, fullContent)
+		if err != nil {
+			return nil, tui.LiveOptions{}, err
 		}
-		fmt.Printf("URL: %s\n", article.Link)
+		opts.Cache = newsService.Cache()
+		tabService, err := news.NewNewsServiceFromOptions(opts)
+		if err != nil {
+			return nil, tui.LiveOptions{}, fmt.Errorf("failed to create news service: %w", err)
+		}
+
+		var highlight string
+		if tab.Kind == config.TabQuery {
+			highlight = tab.Value
+		}
+
+		return cachedArticles(tabService, filter, limit), tui.LiveOptions{
+			Fetcher:         tabService,
+			Filter:          filter,
+			Limit:           limit,
+			RefreshInterval: autoRefresh,
+			Content:         contentFetcher(tabService),
+			Highlight:       highlight,
+		}, nil
 	}
-	return nil
 }
@@ -0,0 +1,188 @@
+// Package cachesync moves the article cache between machines: as a
+// portable archive, or by merging with a shared folder that every device
+// writes its own change log to.
+package cachesync
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/state"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// archiveVersion is the version of the archive layout
+const archiveVersion = 1
+
+// zstdMagic starts zstd compressed files
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// Manifest describes an archive
+type Manifest struct {
+	Version  int       `json:"version"`
+	Created  time.Time `json:"created"`
+	Device   string    `json:"device"`
+	Articles int       `json:"articles"`
+}
+
+// Export writes the cached articles to path as a tar archive with a
+// manifest.json, an articles.json, the fetch history of the sources in
+// feeds.json and the reading positions of the articles in positions.json.
+// Paths ending in .zst are zstd compressed, anything else gzip compressed.
+func Export(path string, cache *news.ArticleCache) (Manifest, error) {
+	articles := cache.GetCachedArticles(0)
+	manifest := Manifest{
+		Version:  archiveVersion,
+		Created:  time.Now(),
+		Device:   DeviceID(),
+		Articles: len(articles),
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	articleData, err := json.Marshal(articles)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to marshal articles: %w", err)
+	}
+	feedData, err := json.Marshal(news.LoadFeedStates())
+	if err != nil {
+		return manifest, fmt.Errorf("failed to marshal feed states: %w", err)
+	}
+	positionData, err := json.Marshal(state.ReadPositions())
+	if err != nil {
+		return manifest, fmt.Errorf("failed to marshal reading positions: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var compressed io.WriteCloser
+	if strings.HasSuffix(path, ".zst") {
+		compressed, err = zstd.NewWriter(f)
+		if err != nil {
+			return manifest, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	} else {
+		compressed = gzip.NewWriter(f)
+	}
+
+	tw := tar.NewWriter(compressed)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{"manifest.json", manifestData},
+		{"articles.json", articleData},
+		{"feeds.json", feedData},
+		{"positions.json", positionData},
+	} {
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    int64(len(file.data)),
+			ModTime: manifest.Created,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return manifest, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return manifest, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return manifest, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := compressed.Close(); err != nil {
+		return manifest, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return manifest, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// Import merges an archive written by Export into cache, along with its
+// feed fetch history and reading positions. The compression is detected
+// from the content, not the file name.
+func Import(path string, cache *news.ArticleCache) (Manifest, news.MergeResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Manifest{}, news.MergeResult{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var decompressed io.Reader
+	r := bufio.NewReader(f)
+	if magic, _ := r.Peek(len(zstdMagic)); bytes.Equal(magic, zstdMagic) {
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return Manifest{}, news.MergeResult{}, fmt.Errorf("%s is not a cache archive: %w", path, err)
+		}
+		defer zr.Close()
+		decompressed = zr
+	} else {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return Manifest{}, news.MergeResult{}, fmt.Errorf("%s is not a cache archive: %w", path, err)
+		}
+		decompressed = gz
+	}
+
+	var manifest Manifest
+	var articles []news.Article
+	var feeds map[string]news.FeedState
+	var positions map[string]state.ReadPosition
+	tr := tar.NewReader(decompressed)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest, news.MergeResult{}, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		switch header.Name {
+		case "manifest.json":
+			err = json.NewDecoder(tr).Decode(&manifest)
+		case "articles.json":
+			err = json.NewDecoder(tr).Decode(&articles)
+		case "feeds.json":
+			err = json.NewDecoder(tr).Decode(&feeds)
+		case "positions.json":
+			err = json.NewDecoder(tr).Decode(&positions)
+		}
+		if err != nil {
+			return manifest, news.MergeResult{}, fmt.Errorf("failed to read %s from %s: %w", header.Name, path, err)
+		}
+	}
+
+	if manifest.Version == 0 {
+		return manifest, news.MergeResult{}, fmt.Errorf("%s is not a cache archive: no manifest", path)
+	}
+	if manifest.Version > archiveVersion {
+		return manifest, news.MergeResult{}, fmt.Errorf("%s was written by a newer nwcli (archive version %d)", path, manifest.Version)
+	}
+
+	news.MergeFeedStates(feeds)
+	if _, err := state.MergeReadPositions(positions); err != nil {
+		return manifest, news.MergeResult{}, fmt.Errorf("failed to save reading positions: %w", err)
+	}
+	return manifest, cache.Merge(articles), nil
+}
@@ -0,0 +1,143 @@
+package cachesync
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/state"
+)
+
+// device switches HOME to a fresh directory, so the cache, feed states
+// and reading positions are those of another machine
+func device(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestArchiveMergeRules(t *testing.T) {
+	earlier := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		file string
+		zstd bool
+	}{
+		{name: "gzip", file: "cache.tar.gz"},
+		{name: "zstd", file: "cache.tar.zst", zstd: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+
+			// The exporting device edited one article, read it last and
+			// fetched NOS last
+			device(t)
+			remote := news.NewArticleCache()
+			remote.Merge([]news.Article{
+				{GUID: "shared", Link: "https://example.com/shared", Title: "Edited", Published: earlier, Updated: later, Categories: []string{"Binnenland"}},
+				{GUID: "remote", Link: "https://example.com/remote", Title: "Remote only", Published: earlier},
+			})
+			news.MergeFeedStates(map[string]news.FeedState{
+				"NOS":   {LastAttempt: later, LastItems: 30},
+				"NU.nl": {LastAttempt: earlier, LastItems: 10},
+				"Trouw": {LastAttempt: earlier, LastItems: 5},
+			})
+			if _, err := state.MergeReadPositions(map[string]state.ReadPosition{
+				"shared": {Progress: 0.8, Read: later},
+				"local":  {Progress: 0.1, Read: earlier},
+			}); err != nil {
+				t.Fatalf("MergeReadPositions: %v", err)
+			}
+
+			manifest, err := Export(path, remote)
+			if err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			if manifest.Articles != 2 || manifest.Version != archiveVersion {
+				t.Errorf("manifest = %+v", manifest)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := bytes.HasPrefix(data, zstdMagic); got != tt.zstd {
+				t.Errorf("zstd compressed = %v, want %v", got, tt.zstd)
+			}
+
+			// The importing device has the page text of the shared article,
+			// read another article last and fetched NU.nl last
+			device(t)
+			local := news.NewArticleCache()
+			local.Merge([]news.Article{
+				{GUID: "shared", Link: "https://example.com/shared", Title: "Original", Published: earlier, Content: "page text", FullContent: true, Categories: []string{"Politiek"}},
+				{GUID: "local", Link: "https://example.com/local", Title: "Local only", Published: earlier},
+			})
+			news.MergeFeedStates(map[string]news.FeedState{
+				"NOS":   {LastAttempt: earlier, LastItems: 20},
+				"NU.nl": {LastAttempt: later, LastItems: 15},
+			})
+			if _, err := state.MergeReadPositions(map[string]state.ReadPosition{
+				"shared": {Progress: 0.2, Read: earlier},
+				"local":  {Progress: 0.9, Read: later},
+			}); err != nil {
+				t.Fatalf("MergeReadPositions: %v", err)
+			}
+
+			_, result, err := Import(path, local)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if want := (news.MergeResult{Added: 1, Updated: 1}); result != want {
+				t.Errorf("Import = %+v, want %+v", result, want)
+			}
+
+			articles := make(map[string]news.Article)
+			for _, article := range local.GetCachedArticles(0) {
+				articles[article.Key()] = article
+			}
+			if len(articles) != 3 {
+				t.Errorf("cache has %d articles, want 3", len(articles))
+			}
+			shared := articles["shared"]
+			if shared.Title != "Edited" || shared.Content != "page text" || !shared.FullContent {
+				t.Errorf("shared = %q %q full %v, want the edit with the page text", shared.Title, shared.Content, shared.FullContent)
+			}
+			if len(shared.Categories) != 2 {
+				t.Errorf("shared categories = %v, want both", shared.Categories)
+			}
+
+			// For each source the history with the latest attempt wins
+			feeds := news.LoadFeedStates()
+			for source, want := range map[string]int{"NOS": 30, "NU.nl": 15, "Trouw": 5} {
+				if got := feeds[source].LastItems; got != want {
+					t.Errorf("%s last items = %d, want %d", source, got, want)
+				}
+			}
+
+			// For each article the position read last wins
+			positions := state.ReadPositions()
+			for key, want := range map[string]float64{"shared": 0.8, "local": 0.9} {
+				if got := positions[key].Progress; got != want {
+					t.Errorf("%s progress = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportRejectsOtherFiles(t *testing.T) {
+	device(t)
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("not an archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Import(path, news.NewArticleCache()); err == nil {
+		t.Errorf("Import accepted a text file")
+	}
+}
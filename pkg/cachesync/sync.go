@@ -0,0 +1,395 @@
+package cachesync
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/news"
+)
+
+// logSuffix ends the name of every change log in a shared folder. Logs are
+// named <device>.<generation><logSuffix>; a device starts a new generation
+// when it compacts its log.
+const logSuffix = ".changes.ndjson"
+
+// minCompactEntries is the size below which a change log is never compacted
+const minCompactEntries = 100
+
+// logEntry is a line of a device's change log: a version of an article or
+// the fetch history of the sources
+type logEntry struct {
+	Time    time.Time                 `json:"time"`
+	Article *news.Article             `json:"article,omitempty"`
+	Feeds   map[string]news.FeedState `json:"feeds,omitempty"`
+}
+
+// folderState is what this device remembers about a shared folder
+type folderState struct {
+	// Offsets are the bytes of each change log already merged, by file name
+	Offsets map[string]int64 `json:"offsets"`
+	// Hashes are the hashes of the article versions this device last wrote
+	// to its log or merged from another device's, by article key
+	Hashes map[string]string `json:"hashes"`
+	// Feeds is the hash of the fetch history after the last sync
+	Feeds string `json:"feeds,omitempty"`
+}
+
+// SyncResult describes a sync
+type SyncResult struct {
+	news.MergeResult
+	// Devices is the number of other devices with a change log
+	Devices int
+	// Pulled is the number of changes read from other devices
+	Pulled int
+	// Pushed is the number of changes written to this device's log
+	Pushed int
+	// Compacted is set when this device's log was rewritten without the
+	// versions that later changes replaced
+	Compacted bool
+}
+
+// Sync merges the cache with a shared folder, such as one kept in sync by
+// Syncthing. Every device appends the articles it added or changed and
+// its fetch history to its own change log in the folder, so no file has
+// more than one writer, and merges the changes of the other devices it has
+// not seen yet. A log is compacted once most of its entries are replaced
+// by later ones.
+func Sync(dir string, cache *news.ArticleCache) (SyncResult, error) {
+	var result SyncResult
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return result, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return result, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	states := loadStates()
+	state := states[dir]
+	if state.Hashes == nil {
+		state.Hashes = make(map[string]string)
+	}
+	feedsChanged := hashFeeds(news.LoadFeedStates()) != state.Feeds
+
+	device := DeviceID()
+	logs, err := filepath.Glob(filepath.Join(dir, "*"+logSuffix))
+	if err != nil {
+		return result, err
+	}
+
+	// Offsets of logs that are gone, replaced by a compacted generation,
+	// are dropped
+	offsets := make(map[string]int64)
+	devices := make(map[string]bool)
+	var ownLog string
+	var pulled []news.Article
+	pulledFeeds := make(map[string]news.FeedState)
+	for _, path := range logs {
+		name := filepath.Base(path)
+		other := logDevice(name)
+		if other == device {
+			ownLog = path
+			continue
+		}
+		devices[other] = true
+
+		entries, offset, err := readLog(path, state.Offsets[name])
+		if err != nil {
+			return result, err
+		}
+		offsets[name] = offset
+		for _, entry := range entries {
+			result.Pulled++
+			if entry.Article != nil {
+				pulled = append(pulled, *entry.Article)
+				state.Hashes[entry.Article.Key()] = hashArticle(*entry.Article)
+			}
+			for source, feed := range entry.Feeds {
+				if feed.LastAttempt.After(pulledFeeds[source].LastAttempt) {
+					pulledFeeds[source] = feed
+				}
+			}
+		}
+	}
+	result.Devices = len(devices)
+	result.MergeResult = cache.Merge(pulled)
+	news.MergeFeedStates(pulledFeeds)
+
+	var changes []logEntry
+	now := time.Now()
+	articles := cache.GetCachedArticles(0)
+	cached := make(map[string]bool, len(articles))
+	for _, article := range articles {
+		cached[article.Key()] = true
+		hash := hashArticle(article)
+		if state.Hashes[article.Key()] != hash {
+			changes = append(changes, logEntry{Time: now, Article: &article})
+			state.Hashes[article.Key()] = hash
+		}
+	}
+	// Forget articles that left the cache
+	for key := range state.Hashes {
+		if !cached[key] {
+			delete(state.Hashes, key)
+		}
+	}
+
+	// Only fetch history recorded on this device is pushed, so merged
+	// history is not echoed back to the devices it came from
+	feeds := news.LoadFeedStates()
+	if feedsChanged && len(feeds) > 0 {
+		changes = append(changes, logEntry{Time: now, Feeds: feeds})
+	}
+	state.Feeds = hashFeeds(feeds)
+
+	if ownLog == "" {
+		ownLog = filepath.Join(dir, logName(device))
+	}
+	ownLog, result.Compacted, err = writeChanges(ownLog, device, changes, cached)
+	if err != nil {
+		return result, err
+	}
+	result.Pushed = len(changes)
+
+	state.Offsets = offsets
+	states[dir] = state
+	return result, saveStates(states)
+}
+
+// writeChanges adds changes to this device's change log. When most of the
+// log's entries are replaced by later ones, the log is rewritten under a
+// new generation with the latest version of each cached article and the
+// latest fetch history, and the old generation removed; other devices read
+// the new generation from the start. It returns the path of the log.
+func writeChanges(path, device string, changes []logEntry, cached map[string]bool) (string, bool, error) {
+	entries, _, err := readLog(path, 0)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return path, false, err
+	}
+	entries = append(entries, changes...)
+
+	live := liveEntries(entries, cached)
+	if len(entries) < minCompactEntries || len(entries) <= 2*len(live) {
+		return path, false, appendLog(path, changes)
+	}
+
+	compacted := filepath.Join(filepath.Dir(path), logName(device))
+	if err := writeLog(compacted, live); err != nil {
+		return path, false, err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return compacted, true, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return compacted, true, nil
+}
+
+// liveEntries keeps the latest entry of each cached article and the latest
+// fetch history, in log order
+func liveEntries(entries []logEntry, cached map[string]bool) []logEntry {
+	latest := make(map[string]int)
+	lastFeeds := -1
+	for i, entry := range entries {
+		if entry.Article != nil {
+			latest[entry.Article.Key()] = i
+		} else if entry.Feeds != nil {
+			lastFeeds = i
+		}
+	}
+
+	var live []logEntry
+	for i, entry := range entries {
+		if entry.Article != nil && cached[entry.Article.Key()] && latest[entry.Article.Key()] == i || i == lastFeeds {
+			live = append(live, entry)
+		}
+	}
+	return live
+}
+
+// logName returns the file name of a new generation of a device's log
+func logName(device string) string {
+	return device + "." + strconv.FormatInt(time.Now().UnixNano(), 36) + logSuffix
+}
+
+// logDevice returns the device a change log belongs to. Device IDs have no
+// dots, so the ID ends at the first one.
+func logDevice(name string) string {
+	device, _, _ := strings.Cut(strings.TrimSuffix(name, logSuffix), ".")
+	return device
+}
+
+// readLog reads the complete lines of a change log after offset. A line
+// still being written by a sync tool is left for the next sync.
+func readLog(path string, offset int64) ([]logEntry, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	// A log shorter than what was read before was replaced, so read it
+	// again from the start
+	if info, err := f.Stat(); err == nil && info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var entries []logEntry
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return entries, offset, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		offset += int64(len(line))
+
+		var entry logEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// Skip damaged lines rather than blocking every later change
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, offset, nil
+}
+
+// appendLog adds entries to this device's change log
+func appendLog(path string, entries []logEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writeLog replaces a change log with entries. The log is written to a
+// temporary file first, so sync tools never pick up half of it.
+func writeLog(path string, entries []logEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// hashArticle identifies a version of an article
+func hashArticle(article news.Article) string {
+	data, _ := json.Marshal(article)
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashFeeds identifies a version of the fetch history
+func hashFeeds(feeds map[string]news.FeedState) string {
+	if len(feeds) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(feeds)
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// statePath returns the location of the sync state file
+func statePath() string {
+	return filepath.Join(config.Dir(), "sync_state.json")
+}
+
+// loadStates reads the sync state of every shared folder
+func loadStates() map[string]folderState {
+	states := make(map[string]folderState)
+	if data, err := os.ReadFile(statePath()); err == nil {
+		json.Unmarshal(data, &states)
+	}
+	return states
+}
+
+// saveStates writes the sync state of every shared folder
+func saveStates(states map[string]folderState) error {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := os.WriteFile(statePath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return nil
+}
+
+// DeviceID returns the name this machine uses in shared folders: its host
+// name and a random suffix, created on first use
+func DeviceID() string {
+	path := filepath.Join(config.Dir(), "device_id")
+	if data, err := os.ReadFile(path); err == nil {
+		if id := strings.TrimSpace(string(data)); id != "" {
+			return id
+		}
+	}
+
+	host, _ := os.Hostname()
+	host = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, host)
+	if host == "" {
+		host = "device"
+	}
+
+	suffix := make([]byte, 3)
+	rand.Read(suffix)
+	id := host + "-" + hex.EncodeToString(suffix)
+
+	os.MkdirAll(config.Dir(), 0755)
+	os.WriteFile(path, []byte(id+"\n"), 0644)
+	return id
+}
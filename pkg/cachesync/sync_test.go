@@ -0,0 +1,156 @@
+package cachesync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nwcli/pkg/news"
+)
+
+func TestSync(t *testing.T) {
+	folder := t.TempDir()
+	homes := map[string]string{"laptop": t.TempDir(), "desktop": t.TempDir()}
+	published := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name     string
+		device   string
+		articles []news.Article
+		feeds    map[string]news.FeedState
+		want     SyncResult
+		titles   map[string]string
+		lastItem map[string]int
+	}{
+		{
+			name:   "laptop pushes its cache and fetch history",
+			device: "laptop",
+			articles: []news.Article{
+				{GUID: "a", Link: "https://example.com/a", Title: "A", Published: published},
+				{GUID: "b", Link: "https://example.com/b", Title: "B", Published: published},
+			},
+			feeds:  map[string]news.FeedState{"NOS": {LastAttempt: published, LastItems: 30}},
+			want:   SyncResult{Pushed: 3},
+			titles: map[string]string{"a": "A", "b": "B"},
+		},
+		{
+			name:   "desktop keeps its newer edit and pushes it",
+			device: "desktop",
+			articles: []news.Article{
+				{GUID: "b", Link: "https://example.com/b", Title: "B edited", Published: published, Updated: published.Add(time.Hour)},
+				{GUID: "c", Link: "https://example.com/c", Title: "C", Published: published},
+			},
+			feeds: map[string]news.FeedState{"NU.nl": {LastAttempt: published, LastItems: 10}},
+			want: SyncResult{
+				MergeResult: news.MergeResult{Added: 1},
+				Devices:     1,
+				Pulled:      3,
+				Pushed:      3,
+			},
+			titles:   map[string]string{"a": "A", "b": "B edited", "c": "C"},
+			lastItem: map[string]int{"NOS": 30, "NU.nl": 10},
+		},
+		{
+			name:   "laptop pulls only what is new",
+			device: "laptop",
+			want: SyncResult{
+				MergeResult: news.MergeResult{Added: 1, Updated: 1},
+				Devices:     1,
+				Pulled:      3,
+			},
+			titles:   map[string]string{"a": "A", "b": "B edited", "c": "C"},
+			lastItem: map[string]int{"NOS": 30, "NU.nl": 10},
+		},
+		{
+			name:   "merged changes are not echoed back",
+			device: "desktop",
+			want:   SyncResult{Devices: 1},
+			titles: map[string]string{"a": "A", "b": "B edited", "c": "C"},
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			t.Setenv("HOME", homes[step.device])
+			cache := news.NewArticleCache()
+			cache.Merge(step.articles)
+			news.MergeFeedStates(step.feeds)
+
+			result, err := Sync(folder, cache)
+			if err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+			if result != step.want {
+				t.Errorf("Sync = %+v, want %+v", result, step.want)
+			}
+
+			titles := make(map[string]string)
+			for _, article := range news.NewArticleCache().GetCachedArticles(0) {
+				titles[article.Key()] = article.Title
+			}
+			if len(titles) != len(step.titles) {
+				t.Errorf("cache = %v, want %v", titles, step.titles)
+			}
+			for key, want := range step.titles {
+				if titles[key] != want {
+					t.Errorf("%s title = %q, want %q", key, titles[key], want)
+				}
+			}
+
+			feeds := news.LoadFeedStates()
+			for source, want := range step.lastItem {
+				if got := feeds[source].LastItems; got != want {
+					t.Errorf("%s last items = %d, want %d", source, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncCompactsLog(t *testing.T) {
+	folder := t.TempDir()
+	laptop, desktop := t.TempDir(), t.TempDir()
+	published := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+
+	// The laptop edits the same article on every sync
+	t.Setenv("HOME", laptop)
+	compacted := false
+	for i := 0; i < 2*minCompactEntries; i++ {
+		cache := news.NewArticleCache()
+		cache.Merge([]news.Article{{GUID: "a", Link: "https://example.com/a", Title: "Edit " + strings.Repeat("!", i), Published: published, Updated: published.Add(time.Duration(i) * time.Minute)}})
+		result, err := Sync(folder, cache)
+		if err != nil {
+			t.Fatalf("Sync %d: %v", i, err)
+		}
+		compacted = compacted || result.Compacted
+	}
+	if !compacted {
+		t.Errorf("log was never compacted")
+	}
+
+	logs, err := filepath.Glob(filepath.Join(folder, "*"+logSuffix))
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("logs = %v, %v, want one", logs, err)
+	}
+	entries, _, err := readLog(logs[0], 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) >= minCompactEntries {
+		t.Errorf("log has %d entries after compaction", len(entries))
+	}
+	if _, err := os.Stat(filepath.Join(laptop, ".nwcli", "sync_state.json")); err != nil {
+		t.Errorf("sync state not saved: %v", err)
+	}
+
+	// Another device still gets the latest edit
+	t.Setenv("HOME", desktop)
+	if _, err := Sync(folder, news.NewArticleCache()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	articles := news.NewArticleCache().GetCachedArticles(0)
+	if want := "Edit " + strings.Repeat("!", 2*minCompactEntries-1); len(articles) != 1 || articles[0].Title != want {
+		t.Errorf("desktop cache = %+v, want the last edit", articles)
+	}
+}
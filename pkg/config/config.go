@@ -0,0 +1,234 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config represents the user configuration stored in ~/.nwcli/config.json
+type Config struct {
+	HTTP    HTTPConfig    `json:"http"`
+	Offline bool          `json:"offline,omitempty"`
+	TUI     TUIConfig     `json:"tui"`
+	Mail    MailConfig    `json:"mail"`
+	Cache   CacheConfig   `json:"cache"`
+	Summary SummaryConfig `json:"summary"`
+	// Topics are saved searches, run with 'nwcli topic <name>'
+	Topics []Topic `json:"topics,omitempty"`
+}
+
+// Topic is a saved search
+type Topic struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// Country, Source and Media narrow the search like the flags of
+	// 'nwcli search'; an empty country means nl
+	Country string `json:"country,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Media   string `json:"media,omitempty"`
+}
+
+// Topic returns the saved search with the given name, ignoring case
+func (c *Config) Topic(name string) (Topic, bool) {
+	for _, topic := range c.Topics {
+		if strings.EqualFold(topic.Name, name) {
+			return topic, true
+		}
+	}
+	return Topic{}, false
+}
+
+// SetTopic saves a search, replacing the one with the same name. It
+// reports whether one was replaced.
+func (c *Config) SetTopic(topic Topic) bool {
+	for i, existing := range c.Topics {
+		if strings.EqualFold(existing.Name, topic.Name) {
+			c.Topics[i] = topic
+			return true
+		}
+	}
+	c.Topics = append(c.Topics, topic)
+	return false
+}
+
+// RemoveTopic deletes a saved search and reports whether it existed
+func (c *Config) RemoveTopic(name string) bool {
+	for i, topic := range c.Topics {
+		if strings.EqualFold(topic.Name, name) {
+			c.Topics = append(c.Topics[:i], c.Topics[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SummaryConfig holds settings for extractive article summaries
+type SummaryConfig struct {
+	// Sentences is the length of the summary added to every article; zero
+	// disables summaries unless --summary asks for them
+	Sentences int `json:"sentences,omitempty"`
+}
+
+// CacheConfig holds settings for serving sources from the cache
+type CacheConfig struct {
+	// TTL is how long a fetched source stays fresh when its feed does not
+	// advertise a <ttl> or sy:updatePeriod, as a Go duration string; empty
+	// uses 30m
+	TTL string `json:"ttl,omitempty"`
+	// Sources overrides the TTL of sources by name, including the TTL
+	// their feed advertises
+	Sources map[string]string `json:"sources,omitempty"`
+	// StaleWhileRevalidate is how long after expiring a source is still
+	// served from the cache while it is refetched in the background; empty
+	// uses 24h and "0" always waits for the fetch
+	StaleWhileRevalidate string `json:"stale_while_revalidate,omitempty"`
+}
+
+// TUIConfig holds settings for the interactive reader
+type TUIConfig struct {
+	// Tabs are the feed views opened next to the command's own view
+	Tabs []Tab `json:"tabs,omitempty"`
+	// SplitMinWidth is the narrowest terminal that shows the list and the
+	// article preview side by side; zero uses the default of 120 columns
+	SplitMinWidth int `json:"split_min_width,omitempty"`
+	// Keymap is the key binding preset: default, vim or emacs
+	Keymap string `json:"keymap,omitempty"`
+	// Keys rebinds actions, e.g. {"quit": ["q", "ctrl+q"]}
+	Keys map[string][]string `json:"keys,omitempty"`
+	// Theme is auto, dark, light, dracula or the path to a JSON theme file
+	Theme string `json:"theme,omitempty"`
+	// SaveDir is where articles are saved as markdown; empty means
+	// ~/.nwcli/saved
+	SaveDir string `json:"save_dir,omitempty"`
+	// ReaderMode starts the article view in reader mode
+	ReaderMode bool `json:"reader_mode,omitempty"`
+	// ReaderWidth is the text column width in reader mode; zero uses the
+	// default of 72 columns
+	ReaderWidth int `json:"reader_width,omitempty"`
+}
+
+// Tab kinds
+const (
+	TabCountry  = "country"
+	TabCategory = "category"
+	TabSource   = "source"
+	TabQuery    = "query"
+)
+
+// Tab is a feed view in the TUI: a country, category, source or saved query
+type Tab struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+	// Country scopes category, source and query tabs; empty means the
+	// country the reader was started with
+	Country string `json:"country,omitempty"`
+}
+
+// HTTPConfig holds settings for the HTTP client used to fetch feeds
+type HTTPConfig struct {
+	UserAgent string `json:"user_agent,omitempty"`
+	// Proxy is a proxy URL (http://, https:// or socks5://). When empty the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables are used.
+	Proxy string `json:"proxy,omitempty"`
+	// CACerts lists PEM files with extra root certificates to trust
+	CACerts []string `json:"ca_certs,omitempty"`
+	// Timeout is a Go duration string such as "30s" or "1m"
+	Timeout string `json:"timeout,omitempty"`
+	// Headers maps a source name to extra request headers for that source.
+	// The special source name "*" applies to every source.
+	Headers map[string]map[string]string `json:"headers,omitempty"`
+}
+
+// MailConfig holds settings for sending digests by email
+type MailConfig struct {
+	// Host and Port address the SMTP server; the port defaults to 465 with
+	// TLS and 587 otherwise
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// Security is starttls (the default), tls or none
+	Security string `json:"security,omitempty"`
+	// Auth is plain (the default), login or none
+	Auth     string `json:"auth,omitempty"`
+	Username string `json:"username,omitempty"`
+	// Password may be left empty and set in $NWCLI_SMTP_PASSWORD instead
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+	// Subject is a Go template with .Title, .Country, .Date and .Count;
+	// empty uses the digest title
+	Subject string `json:"subject,omitempty"`
+	// InlineImages embeds article images in the message instead of
+	// linking to them
+	InlineImages bool `json:"inline_images,omitempty"`
+}
+
+// Dir returns the nwcli configuration directory
+func Dir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".nwcli")
+}
+
+// Path returns the path of the configuration file
+func Path() string {
+	if path := os.Getenv("NWCLI_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(Dir(), "config.json")
+}
+
+// Load reads the configuration file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", Path(), err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes the configuration file
+func (c *Config) Save() error {
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
+
+// TimeoutDuration returns the configured request timeout, or zero if unset
+func (h HTTPConfig) TimeoutDuration() (time.Duration, error) {
+	if h.Timeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(h.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid http timeout %q: %w", h.Timeout, err)
+	}
+
+	return timeout, nil
+}
@@ -0,0 +1,54 @@
+package edition
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DateLayout names the edition directories of an archive
+const DateLayout = "2006-01-02"
+
+// archiveEdition is an edition listed in the archive index
+type archiveEdition struct {
+	Dir  string
+	Date time.Time
+}
+
+// WriteArchive writes index.html in root, listing the dated editions in
+// its subdirectories, newest first
+func WriteArchive(root, title string) error {
+	dirEntries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("failed to read edition archive: %w", err)
+	}
+
+	var editions []archiveEdition
+	for _, de := range dirEntries {
+		if !de.IsDir() {
+			continue
+		}
+		date, err := time.Parse(DateLayout, de.Name())
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(root, de.Name(), "index.html")); err != nil {
+			continue
+		}
+		editions = append(editions, archiveEdition{Dir: de.Name(), Date: date})
+	}
+
+	sort.Slice(editions, func(i, j int) bool {
+		return editions[i].Date.After(editions[j].Date)
+	})
+
+	if err := writeFile(filepath.Join(root, "style.css"), styleSheet); err != nil {
+		return err
+	}
+	return writeTemplate(filepath.Join(root, "index.html"), "archive", struct {
+		Title    string
+		Editions []archiveEdition
+	}{title, editions})
+}
@@ -0,0 +1,365 @@
+// Package edition builds static, self-contained news sites from cached
+// articles. An edition is a directory with a front page, one page per
+// article, local copies of article images and a search index, and can be
+// opened straight from disk without a web server.
+package edition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/offline"
+	"nwcli/pkg/renderer"
+)
+
+// searchTextLimit caps the article text stored in the search index
+const searchTextLimit = 4000
+
+// Options configures an edition
+type Options struct {
+	// Dir is the directory the edition is written to
+	Dir string
+	// Title is shown in the masthead
+	Title string
+	// Language is the language of the front page, e.g. "nl"
+	Language string
+	// Date is the date of the edition
+	Date time.Time
+	// Articles are the articles of the edition, lead story first
+	Articles []news.Article
+	// Fetcher downloads full text and images; nil builds the edition from
+	// the cache alone
+	Fetcher offline.Fetcher
+	// Images downloads article images when set
+	Images bool
+	// Archived links the front page to the archive index in the parent
+	// directory
+	Archived bool
+	// Progress is called after each article is prepared
+	Progress func(done, total int)
+}
+
+// Result summarizes a build
+type Result struct {
+	Articles int
+	FullText int
+	Images   int
+	// Failures lists the articles whose text or image could not be loaded
+	Failures []string
+}
+
+// entry is an article with the files it links to
+type entry struct {
+	news.Article
+	Page  string
+	Image string
+}
+
+// searchEntry is an article in the search index
+type searchEntry struct {
+	Title     string `json:"title"`
+	Source    string `json:"source"`
+	Published string `json:"published,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	Text      string `json:"text,omitempty"`
+	URL       string `json:"url"`
+}
+
+// Build writes an edition to opts.Dir
+func Build(ctx context.Context, opts Options) (Result, error) {
+	for _, dir := range []string{opts.Dir, filepath.Join(opts.Dir, "articles"), filepath.Join(opts.Dir, "images")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return Result{}, fmt.Errorf("failed to create edition directory: %w", err)
+		}
+	}
+
+	entries, result := prepare(ctx, opts)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	files := map[string]string{
+		"style.css": styleSheet,
+		"search.js": searchScript,
+	}
+	for name, content := range files {
+		if err := writeFile(filepath.Join(opts.Dir, name), content); err != nil {
+			return result, err
+		}
+	}
+
+	if err := writeSearchIndex(opts.Dir, entries); err != nil {
+		return result, err
+	}
+
+	for i, e := range entries {
+		page := articlePage{Entry: e, Edition: opts.Title, Date: opts.Date}
+		if i > 0 {
+			page.Prev = entries[i-1].Page
+		}
+		if i < len(entries)-1 {
+			page.Next = entries[i+1].Page
+		}
+		if err := writeTemplate(filepath.Join(opts.Dir, e.Page), "article", page); err != nil {
+			return result, err
+		}
+	}
+
+	lang := opts.Language
+	if lang == "" {
+		lang = "en"
+	}
+	front := frontPage{Title: opts.Title, Language: lang, Date: opts.Date, Entries: entries, Archived: opts.Archived}
+	if err := writeTemplate(filepath.Join(opts.Dir, "index.html"), "front", front); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// prepare loads the full text and image of each article, saves the
+// images and assigns the file names of the pages
+func prepare(ctx context.Context, opts Options) ([]entry, Result) {
+	items := offline.Load(ctx, opts.Articles, offline.Options{
+		Fetcher:  opts.Fetcher,
+		Images:   opts.Images,
+		Progress: opts.Progress,
+	})
+
+	entries := make([]entry, 0, len(items))
+	result := Result{Articles: len(items)}
+	for _, item := range items {
+		article := item.Article
+		e := entry{Article: article, Page: "articles/" + article.Slug() + "-" + article.ID() + ".html"}
+		if item.Failure != "" {
+			result.Failures = append(result.Failures, item.Failure)
+		}
+
+		if item.Image != nil {
+			name := "images/" + article.ID() + item.ImageExt
+			if err := os.WriteFile(filepath.Join(opts.Dir, name), item.Image, 0644); err == nil {
+				e.Image = name
+				result.Images++
+			} else {
+				result.Failures = append(result.Failures, fmt.Sprintf("%s (image: %v)", article.Title, err))
+			}
+		}
+		if article.FullContent {
+			result.FullText++
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, result
+}
+
+// writeSearchIndex writes search.json, and search-index.js with the same
+// data for browsers that refuse to fetch files opened from disk
+func writeSearchIndex(dir string, entries []entry) error {
+	index := make([]searchEntry, 0, len(entries))
+	for _, e := range entries {
+		text := strings.Join(strings.Fields(e.Content), " ")
+		if len(text) > searchTextLimit {
+			text = strings.ToValidUTF8(text[:searchTextLimit], "")
+		}
+		se := searchEntry{
+			Title:   e.Title,
+			Source:  e.Source,
+			Summary: renderer.Teaser(e.Article),
+			Text:    text,
+			URL:     e.Page,
+		}
+		if !e.Published.IsZero() {
+			se.Published = e.Published.Format(time.RFC3339)
+		}
+		index = append(index, se)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to build search index: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, "search.json"), string(data)); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, "search-index.js"), "window.searchIndex = "+string(data)+";\n")
+}
+
+// writeTemplate renders the named template to path
+func writeTemplate(path, name string, data any) error {
+	var b strings.Builder
+	if err := templates.ExecuteTemplate(&b, name, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", filepath.Base(path), err)
+	}
+	return writeFile(path, b.String())
+}
+
+// writeFile writes content to path
+func writeFile(path, content string) error {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// frontPage is the data of index.html
+type frontPage struct {
+	Title    string
+	Language string
+	Date     time.Time
+	Entries  []entry
+	Archived bool
+}
+
+// articlePage is the data of an article page
+type articlePage struct {
+	Entry   entry
+	Edition string
+	Date    time.Time
+	Prev    string
+	Next    string
+}
+
+// Lang is the language of the article, defaulting to English
+func (p articlePage) Lang() string {
+	if p.Entry.Language != "" {
+		return p.Entry.Language
+	}
+	return "en"
+}
+
+// templates are the pages of an edition
+var templates = template.Must(template.New("edition").Funcs(renderer.HTMLFuncs).Parse(`
+{{define "front"}}<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<header class="masthead"><h1>{{.Title}}</h1><p class="meta">{{.Date.Format "Monday, January 2, 2006"}} • {{len .Entries}} articles</p></header>
+<input type="search" id="search" placeholder="Search this edition…" autocomplete="off">
+<ol id="results" class="results" hidden></ol>
+<div id="front" class="grid">
+{{range $i, $e := .Entries}}<div class="card{{if eq $i 0}} lead{{end}}">
+{{if $e.Image}}<a href="{{$e.Page}}"><img src="{{$e.Image}}" alt="" loading="lazy"></a>{{end}}
+<h2><a href="{{$e.Page}}">{{$e.Title}}</a></h2>
+<p class="meta">{{byline $e.Article}}</p>
+<p>{{teaser $e.Article}}</p>
+</div>
+{{end}}</div>
+<footer>Generated with NWCLI{{if .Archived}} • <a href="../index.html">All editions</a>{{end}}</footer>
+<script src="search-index.js"></script>
+<script src="search.js"></script>
+</body>
+</html>
+{{end}}
+
+{{define "archive"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<header class="masthead"><h1>{{.Title}}</h1><p class="meta">{{len .Editions}} editions</p></header>
+<ul class="archive">
+{{range .Editions}}<li><a href="{{.Dir}}/index.html">{{.Date.Format "Monday, January 2, 2006"}}</a></li>
+{{end}}</ul>
+<footer>Generated with NWCLI</footer>
+</body>
+</html>
+{{end}}
+
+{{define "article"}}<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Entry.Title}} – {{.Edition}}</title>
+<link rel="stylesheet" href="../style.css">
+</head>
+<body>
+<nav class="pager"><a href="../index.html">← {{.Edition}}</a></nav>
+<article>
+<h1>{{.Entry.Title}}</h1>
+<p class="meta">{{byline .Entry.Article}}</p>
+{{if .Entry.Image}}<img src="../{{.Entry.Image}}" alt="">{{end}}
+{{if .Entry.Summary}}<blockquote>{{.Entry.Summary}}</blockquote>{{end}}
+{{articleHTML .Entry.Article}}
+{{if .Entry.Categories}}<p class="tags">{{range .Entry.Categories}}<span>{{.}}</span>{{end}}</p>{{end}}
+<p><a href="{{.Entry.Link}}">Read the original article</a></p>
+</article>
+<nav class="pager">{{if .Prev}}<a href="../{{.Prev}}">← Previous</a>{{end}}<a href="../index.html">Front page</a>{{if .Next}}<a href="../{{.Next}}">Next →</a>{{end}}</nav>
+<footer>{{.Edition}} • {{.Date.Format "January 2, 2006"}}</footer>
+</body>
+</html>
+{{end}}
+`))
+
+// styleSheet is the stylesheet of edition pages: the HTML output style
+// plus navigation, search results and the archive list
+const styleSheet = renderer.HTMLStyle + `nav.pager { display: flex; justify-content: space-between; max-width: 42rem; margin: 1rem auto; }
+ol.results { list-style: none; padding: 0; }
+ol.results li { border-bottom: 1px solid var(--border); padding: 0.6rem 0; }
+ol.results li p { margin: 0.2rem 0; }
+ul.archive { list-style: none; padding: 0; font-size: 1.2rem; }
+ul.archive li { border-bottom: 1px solid var(--border); padding: 0.6rem 0; }
+`
+
+// searchScript filters the front page with window.searchIndex. Every word
+// of the query must appear in the title, summary or text of an article.
+const searchScript = `(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  var front = document.getElementById("front");
+  var index = (window.searchIndex || []).map(function (e) {
+    return { entry: e, text: [e.title, e.source, e.summary, e.text].join(" ").toLowerCase() };
+  });
+
+  input.addEventListener("input", function () {
+    var words = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+    results.textContent = "";
+    results.hidden = words.length === 0;
+    front.hidden = words.length > 0;
+    if (!words.length) return;
+
+    var hits = index.filter(function (item) {
+      return words.every(function (w) { return item.text.indexOf(w) >= 0; });
+    });
+    if (!hits.length) {
+      var empty = document.createElement("li");
+      empty.textContent = "No articles found";
+      results.appendChild(empty);
+      return;
+    }
+    hits.forEach(function (item) {
+      var li = document.createElement("li");
+      var a = document.createElement("a");
+      a.href = item.entry.url;
+      a.textContent = item.entry.title;
+      var meta = document.createElement("p");
+      meta.className = "meta";
+      meta.textContent = item.entry.source;
+      var summary = document.createElement("p");
+      summary.textContent = item.entry.summary || "";
+      li.appendChild(a);
+      li.appendChild(meta);
+      li.appendChild(summary);
+      results.appendChild(li);
+    });
+  });
+})();
+`
@@ -0,0 +1,458 @@
+// Package epub writes news digests as EPUB 3 books for e-readers: a
+// generated cover, a table of contents grouped by source or category and
+// one chapter per article.
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"nwcli/pkg/offline"
+	"nwcli/pkg/renderer"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// Ways to group the table of contents
+const (
+	GroupSource   = "source"
+	GroupCategory = "category"
+)
+
+// Book describes a digest
+type Book struct {
+	Title string
+	// Country is the name of the country shown on the cover
+	Country string
+	Date    time.Time
+	// Language is the language of the book, used for articles whose
+	// source language is unknown
+	Language string
+	// SourceLanguages maps source names to their language, e.g. "NOS" to
+	// "nl"
+	SourceLanguages map[string]string
+	// Group is GroupSource or GroupCategory
+	Group string
+	Items []offline.Item
+}
+
+// mediaTypes maps image extensions to the media types EPUB readers support
+var mediaTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+}
+
+// chapter is an article in the book
+type chapter struct {
+	offline.Item
+	ID        string
+	File      string
+	ImageFile string
+	ImageType string
+	Lang      string
+	Body      template.HTML
+}
+
+// group is a section of the table of contents
+type group struct {
+	Name     string
+	Chapters []chapter
+}
+
+// Write writes book as an EPUB file to w
+func Write(w io.Writer, book Book) error {
+	if len(book.Items) == 0 {
+		return fmt.Errorf("no articles to write")
+	}
+	if book.Language == "" {
+		book.Language = "en"
+	}
+	groups := groupChapters(book)
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype must come first, uncompressed and without a data
+	// descriptor, so readers can recognize the file from its first bytes
+	mimetype := []byte("application/epub+zip")
+	mw, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write EPUB: %w", err)
+	}
+	if _, err := mw.Write(mimetype); err != nil {
+		return fmt.Errorf("failed to write EPUB: %w", err)
+	}
+
+	data := bookData{
+		Book:       book,
+		Identifier: identifier(book),
+		Modified:   time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		Languages:  languages(book, groups),
+		Groups:     groups,
+	}
+
+	files := []struct {
+		name     string
+		template string
+	}{
+		{"META-INF/container.xml", "container"},
+		{"OEBPS/content.opf", "opf"},
+		{"OEBPS/nav.xhtml", "nav"},
+		{"OEBPS/toc.ncx", "ncx"},
+		{"OEBPS/cover.xhtml", "cover"},
+		{"OEBPS/cover.svg", "coverSVG"},
+	}
+	for _, f := range files {
+		b := bytes.NewBufferString(xml.Header)
+		if err := templates.ExecuteTemplate(b, f.template, data); err != nil {
+			return fmt.Errorf("failed to render %s: %w", f.name, err)
+		}
+		if err := writeEntry(zw, f.name, b.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := writeEntry(zw, "OEBPS/style.css", []byte(styleSheet)); err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		for _, c := range g.Chapters {
+			b := bytes.NewBufferString(xml.Header)
+			if err := templates.ExecuteTemplate(b, "chapter", c); err != nil {
+				return fmt.Errorf("failed to render %s: %w", c.File, err)
+			}
+			if err := writeEntry(zw, "OEBPS/"+c.File, b.Bytes()); err != nil {
+				return err
+			}
+			if c.ImageFile != "" {
+				if err := writeEntry(zw, "OEBPS/"+c.ImageFile, c.Image); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write EPUB: %w", err)
+	}
+	return nil
+}
+
+// writeEntry adds a compressed file to the archive
+func writeEntry(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// groupChapters turns the book's items into chapters, grouped by source or
+// first category in order of first appearance
+func groupChapters(book Book) []group {
+	var groups []group
+	index := make(map[string]int)
+
+	for i, item := range book.Items {
+		name := item.Article.Source
+		if book.Group == GroupCategory {
+			name = "General"
+			if len(item.Article.Categories) > 0 {
+				name = item.Article.Categories[0]
+			}
+		}
+		if _, ok := index[name]; !ok {
+			index[name] = len(groups)
+			groups = append(groups, group{Name: name})
+		}
+
+		c := chapter{
+			Item: item,
+			ID:   fmt.Sprintf("chapter%03d", i+1),
+			File: fmt.Sprintf("chapter%03d.xhtml", i+1),
+			Lang: book.SourceLanguages[item.Article.Source],
+		}
+		if c.Lang == "" {
+			c.Lang = item.Article.Language
+		}
+		if c.Lang == "" {
+			c.Lang = book.Language
+		}
+		if mediaType, ok := mediaTypes[item.ImageExt]; ok && item.Image != nil {
+			c.ImageFile = fmt.Sprintf("images/image%03d%s", i+1, item.ImageExt)
+			c.ImageType = mediaType
+		}
+		c.Body = chapterBody(item)
+
+		g := &groups[index[name]]
+		g.Chapters = append(g.Chapters, c)
+	}
+
+	return groups
+}
+
+// languages returns the book language followed by the other chapter
+// languages
+func languages(book Book, groups []group) []string {
+	langs := []string{book.Language}
+	seen := map[string]bool{book.Language: true}
+	for _, g := range groups {
+		for _, c := range g.Chapters {
+			if !seen[c.Lang] {
+				seen[c.Lang] = true
+				langs = append(langs, c.Lang)
+			}
+		}
+	}
+	return langs
+}
+
+// identifier derives a stable UUID from the book's date and articles, so
+// rebuilding the same digest does not add a second book to a library
+func identifier(book Book) string {
+	h := sha1.New()
+	fmt.Fprintln(h, book.Title, book.Date.Format("2006-01-02"))
+	for _, item := range book.Items {
+		fmt.Fprintln(h, item.Article.ID())
+	}
+	sum := h.Sum(nil)
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// xhtmlMarkdown converts markdown to XHTML, dropping raw HTML
+var xhtmlMarkdown = goldmark.New(goldmark.WithRendererOptions(html.WithXHTML()))
+
+// remoteImage matches images in article text, which e-readers cannot load
+var remoteImage = regexp.MustCompile(`<img[^>]*/>`)
+
+// emptyParagraph matches paragraphs left empty by removing images
+var emptyParagraph = regexp.MustCompile(`<p>\s*</p>\n?`)
+
+// chapterBody converts an article's content, or its description when it
+// has none, to XHTML
+func chapterBody(item offline.Item) template.HTML {
+	text := item.Article.Content
+	if text == "" {
+		text = item.Article.Description
+	}
+
+	var b bytes.Buffer
+	if err := xhtmlMarkdown.Convert([]byte(text), &b); err != nil {
+		return template.HTML("<p>" + template.HTMLEscapeString(text) + "</p>")
+	}
+	body := remoteImage.ReplaceAllString(b.String(), "")
+	return template.HTML(emptyParagraph.ReplaceAllString(body, ""))
+}
+
+// bookData is the data of the package and navigation documents
+type bookData struct {
+	Book
+	Identifier string
+	Modified   string
+	Languages  []string
+	Groups     []group
+}
+
+// Count returns the number of articles in the book
+func (d bookData) Count() int {
+	return len(d.Items)
+}
+
+// Headlines returns up to n article titles for the cover
+func (d bookData) Headlines(n int) []string {
+	var titles []string
+	for _, g := range d.Groups {
+		for _, c := range g.Chapters {
+			if len(titles) == n {
+				return titles
+			}
+			titles = append(titles, c.Article.Title)
+		}
+	}
+	return titles
+}
+
+// templates are the documents of a book. html/template escapes XML
+// declarations, so Write adds them.
+var templates = template.Must(template.New("epub").Funcs(template.FuncMap{
+	"byline":    renderer.Byline,
+	"shorten":   shorten,
+	"add":       func(a, b int) int { return a + b },
+	"headlineY": func(i int) int { return 400 + i*50 },
+}).Parse(`
+{{define "container"}}<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+{{end}}
+
+{{define "opf"}}<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="{{.Language}}">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">{{.Identifier}}</dc:identifier>
+    <dc:title>{{.Title}}, {{.Date.Format "January 2, 2006"}}</dc:title>
+    {{range .Languages}}<dc:language>{{.}}</dc:language>
+    {{end}}<dc:creator>NWCLI</dc:creator>
+    <dc:publisher>NWCLI</dc:publisher>
+    <dc:date>{{.Date.UTC.Format "2006-01-02T15:04:05Z"}}</dc:date>
+    <dc:description>News from {{.Country}}, {{.Date.Format "Monday, January 2, 2006"}}</dc:description>
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+    <meta name="cover" content="cover-image"/>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="cover" href="cover.xhtml" media-type="application/xhtml+xml" properties="svg"/>
+    <item id="cover-image" href="cover.svg" media-type="image/svg+xml" properties="cover-image"/>
+    <item id="style" href="style.css" media-type="text/css"/>
+    {{range .Groups}}{{range .Chapters}}<item id="{{.ID}}" href="{{.File}}" media-type="application/xhtml+xml"/>
+    {{if .ImageFile}}<item id="{{.ID}}-image" href="{{.ImageFile}}" media-type="{{.ImageType}}"/>
+    {{end}}{{end}}{{end}}
+  </manifest>
+  <spine toc="ncx">
+    <itemref idref="cover" linear="yes"/>
+    <itemref idref="nav"/>
+    {{range .Groups}}{{range .Chapters}}<itemref idref="{{.ID}}"/>
+    {{end}}{{end}}
+  </spine>
+</package>
+{{end}}
+
+{{define "nav"}}<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{.Language}}" lang="{{.Language}}">
+<head>
+<title>{{.Title}}</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+<nav epub:type="toc" id="toc">
+<h1>Contents</h1>
+<ol>
+{{range .Groups}}<li><span>{{.Name}}</span>
+<ol>
+{{range .Chapters}}<li><a href="{{.File}}">{{.Article.Title}}</a></li>
+{{end}}</ol>
+</li>
+{{end}}</ol>
+</nav>
+<nav epub:type="landmarks" hidden="hidden">
+<ol>
+<li><a epub:type="cover" href="cover.xhtml">Cover</a></li>
+<li><a epub:type="toc" href="nav.xhtml">Contents</a></li>
+{{with index .Groups 0}}{{with index .Chapters 0}}<li><a epub:type="bodymatter" href="{{.File}}">Articles</a></li>{{end}}{{end}}
+</ol>
+</nav>
+</body>
+</html>
+{{end}}
+
+{{define "ncx"}}<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+<head>
+<meta name="dtb:uid" content="{{.Identifier}}"/>
+<meta name="dtb:depth" content="2"/>
+<meta name="dtb:totalPageCount" content="0"/>
+<meta name="dtb:maxPageNumber" content="0"/>
+</head>
+<docTitle><text>{{.Title}}</text></docTitle>
+<navMap>
+{{range $g, $group := .Groups}}<navPoint id="group{{add $g 1}}">
+<navLabel><text>{{$group.Name}}</text></navLabel>
+<content src="{{(index $group.Chapters 0).File}}"/>
+{{range $group.Chapters}}<navPoint id="nav-{{.ID}}">
+<navLabel><text>{{.Article.Title}}</text></navLabel>
+<content src="{{.File}}"/>
+</navPoint>
+{{end}}</navPoint>
+{{end}}</navMap>
+</ncx>
+{{end}}
+
+{{define "cover"}}<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{.Language}}" lang="{{.Language}}">
+<head>
+<title>{{.Title}}</title>
+<style type="text/css">body { margin: 0; padding: 0; text-align: center; } img { max-width: 100%; max-height: 100%; }</style>
+</head>
+<body epub:type="cover">
+<img src="cover.svg" alt="{{.Title}}"/>
+</body>
+</html>
+{{end}}
+
+{{define "coverSVG"}}<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 600 800" width="600" height="800">
+<rect width="600" height="800" fill="#fdfcf9"/>
+<rect x="0" y="0" width="600" height="16" fill="#c0392b"/>
+<text x="300" y="150" font-family="Georgia, serif" font-size="46" font-weight="bold" text-anchor="middle" fill="#1f2328">{{shorten .Title 22}}</text>
+<line x1="60" y1="185" x2="540" y2="185" stroke="#1f2328" stroke-width="3"/>
+<line x1="60" y1="193" x2="540" y2="193" stroke="#1f2328" stroke-width="1"/>
+<text x="300" y="260" font-family="Georgia, serif" font-size="34" text-anchor="middle" fill="#c0392b">{{.Country}}</text>
+<text x="300" y="310" font-family="Georgia, serif" font-size="26" font-style="italic" text-anchor="middle" fill="#1f2328">{{.Date.Format "Monday, January 2, 2006"}}</text>
+{{range $i, $title := .Headlines 5}}<text x="60" y="{{headlineY $i}}" font-family="Georgia, serif" font-size="20" fill="#1f2328">• {{shorten $title 44}}</text>
+{{end}}<text x="300" y="760" font-family="Georgia, serif" font-size="20" text-anchor="middle" fill="#6a737d">{{.Count}} articles • NWCLI</text>
+</svg>
+{{end}}
+
+{{define "chapter"}}<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{.Lang}}" lang="{{.Lang}}">
+<head>
+<title>{{.Article.Title}}</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+<section epub:type="chapter">
+<h1>{{.Article.Title}}</h1>
+<p class="meta">{{byline .Article}}</p>
+{{if .ImageFile}}<img src="{{.ImageFile}}" alt=""/>{{end}}
+{{if .Article.Summary}}<blockquote><p>{{.Article.Summary}}</p></blockquote>{{end}}
+{{.Body}}
+<p class="source"><a href="{{.Article.Link}}">{{.Article.Link}}</a></p>
+</section>
+</body>
+</html>
+{{end}}
+`))
+
+// styleSheet is the stylesheet of the chapters
+const styleSheet = `body { font-family: Georgia, serif; line-height: 1.5; }
+h1 { font-size: 1.6em; line-height: 1.2; }
+.meta { color: #555; font-style: italic; font-size: 0.9em; }
+img { max-width: 100%; height: auto; }
+blockquote { border-left: 3px solid #c0392b; margin: 1em 0; padding-left: 1em; }
+.source { font-size: 0.8em; word-break: break-all; }
+nav ol { list-style: none; padding-left: 1em; }
+nav span { font-weight: bold; }
+`
+
+// shorten truncates s to n characters for the cover
+func shorten(s string, n int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
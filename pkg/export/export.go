@@ -0,0 +1,240 @@
+// Package export writes articles as tables for analysis tools such as
+// pandas or DuckDB, one row at a time.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/renderer"
+)
+
+// Formats lists the export formats
+var Formats = []string{"csv", "tsv", "ndjson"}
+
+// DefaultFields are exported when no fields are selected, the same columns
+// as the csv output format
+var DefaultFields = renderer.ArticleColumns
+
+// Field is a column of the export. Value returns a string, a bool, a time,
+// or a list of strings for fields with several values.
+type Field struct {
+	Name  string
+	Value func(news.Article) any
+}
+
+// fields are the available columns by name
+var fields = map[string]func(news.Article) any{
+	"id":           func(a news.Article) any { return a.ID() },
+	"title":        func(a news.Article) any { return a.Title },
+	"source":       func(a news.Article) any { return a.Source },
+	"country":      func(a news.Article) any { return sourceCountry(a.Source) },
+	"authors":      func(a news.Article) any { return a.AuthorList() },
+	"published":    func(a news.Article) any { return a.Published },
+	"updated":      func(a news.Article) any { return a.Updated },
+	"link":         func(a news.Article) any { return a.Link },
+	"guid":         func(a news.Article) any { return a.GUID },
+	"categories":   func(a news.Article) any { return a.Categories },
+	"description":  func(a news.Article) any { return a.Description },
+	"summary":      func(a news.Article) any { return a.Summary },
+	"content":      func(a news.Article) any { return a.Content },
+	"image_url":    func(a news.Article) any { return a.ImageURL },
+	"comments_url": func(a news.Article) any { return a.CommentsURL },
+	"language":     func(a news.Article) any { return a.Language },
+	"full_content": func(a news.Article) any { return a.FullContent },
+	"media":        func(a news.Article) any { return mediaURLs(a) },
+}
+
+// FieldNames returns the names of the available fields, sorted
+func FieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseFields returns the fields named in a comma-separated list, or the
+// default fields for an empty list
+func ParseFields(list string) ([]Field, error) {
+	names := DefaultFields
+	if strings.TrimSpace(list) != "" {
+		names = strings.Split(list, ",")
+	}
+
+	var selected []Field
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (available: %s)", name, strings.Join(FieldNames(), ", "))
+		}
+		selected = append(selected, Field{Name: name, Value: value})
+	}
+	return selected, nil
+}
+
+// Writer writes articles as rows
+type Writer interface {
+	Write(article news.Article) error
+	// Close writes any buffered rows
+	Close() error
+}
+
+// NewWriter returns a writer of the given format. CSV and TSV output start
+// with a header row.
+func NewWriter(w io.Writer, format string, fields []Field) (Writer, error) {
+	switch format {
+	case "csv", "tsv":
+		cw := csv.NewWriter(w)
+		if format == "tsv" {
+			cw.Comma = '\t'
+		}
+		header := make([]string, len(fields))
+		for i, field := range fields {
+			header[i] = field.Name
+		}
+		if err := cw.Write(header); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", format, err)
+		}
+		return &csvWriter{w: cw, fields: fields}, nil
+	case "ndjson":
+		return &ndjsonWriter{enc: json.NewEncoder(w), fields: fields}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q (available: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// csvWriter writes comma or tab separated rows. Fields with several values
+// are joined with "; ".
+type csvWriter struct {
+	w      *csv.Writer
+	fields []Field
+	row    []string
+}
+
+func (cw *csvWriter) Write(article news.Article) error {
+	cw.row = cw.row[:0]
+	for _, field := range cw.fields {
+		var cell string
+		switch v := field.Value(article).(type) {
+		case string:
+			cell = v
+		case bool:
+			cell = strconv.FormatBool(v)
+		case time.Time:
+			cell = renderer.FormatTime(v)
+		case []string:
+			cell = strings.Join(v, "; ")
+		}
+		cw.row = append(cw.row, cell)
+	}
+	if err := cw.w.Write(cw.row); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// ndjsonWriter writes one JSON object per line with the fields in order.
+// Fields with several values are arrays and missing times are null.
+type ndjsonWriter struct {
+	enc    *json.Encoder
+	fields []Field
+}
+
+func (nw *ndjsonWriter) Write(article news.Article) error {
+	row := make(orderedObject, 0, len(nw.fields))
+	for _, field := range nw.fields {
+		value := field.Value(article)
+		switch v := value.(type) {
+		case time.Time:
+			if v.IsZero() {
+				value = nil
+			} else {
+				value = renderer.FormatTime(v)
+			}
+		case []string:
+			if v == nil {
+				value = []string{}
+			}
+		}
+		row = append(row, keyValue{field.Name, value})
+	}
+	if err := nw.enc.Encode(row); err != nil {
+		return fmt.Errorf("failed to write NDJSON: %w", err)
+	}
+	return nil
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
+
+// keyValue is a member of an orderedObject
+type keyValue struct {
+	Key   string
+	Value any
+}
+
+// orderedObject marshals as a JSON object with its keys in order
+type orderedObject []keyValue
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, kv := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(kv.Key)
+		value, err := json.Marshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// sourceCountries memoizes news.CountryForSource, which is called for
+// every row
+var sourceCountries sync.Map
+
+// sourceCountry returns the country code of a source
+func sourceCountry(source string) string {
+	if country, ok := sourceCountries.Load(source); ok {
+		return country.(string)
+	}
+	country := news.CountryForSource(source)
+	sourceCountries.Store(source, country)
+	return country
+}
+
+// mediaURLs lists the URLs of the article's audio and video
+func mediaURLs(a news.Article) []string {
+	var urls []string
+	for _, media := range a.Media {
+		urls = append(urls, media.URL)
+	}
+	return urls
+}
@@ -0,0 +1,162 @@
+// Package mail composes multipart email messages and sends them over SMTP
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Image is an image shown inline in the HTML part, referenced from it as
+// cid:<ContentID>
+type Image struct {
+	ContentID string
+	Type      string
+	Filename  string
+	Data      []byte
+}
+
+// Message is an email with an HTML body and a plain text alternative
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Date    time.Time
+	HTML    string
+	Text    string
+	Images  []Image
+}
+
+// WriteTo writes the message in RFC 5322 format, as sent over SMTP or
+// saved in an .eml file
+func (m Message) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	alternative := multipart.NewWriter(&b)
+
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	headers := []struct{ name, value string }{
+		{"From", m.From},
+		{"To", strings.Join(m.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", messageID(m.From, date)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + alternative.Boundary()},
+	}
+	for _, h := range headers {
+		if h.value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s\r\n", h.name, h.value)
+	}
+	b.WriteString("\r\n")
+
+	if err := writeText(alternative, "text/plain; charset=utf-8", m.Text); err != nil {
+		return 0, err
+	}
+
+	if len(m.Images) == 0 {
+		if err := writeText(alternative, "text/html; charset=utf-8", m.HTML); err != nil {
+			return 0, err
+		}
+	} else if err := m.writeRelated(alternative); err != nil {
+		return 0, err
+	}
+
+	if err := alternative.Close(); err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// writeRelated writes the HTML part together with its inline images
+func (m Message) writeRelated(alternative *multipart.Writer) error {
+	var b bytes.Buffer
+	related := multipart.NewWriter(&b)
+
+	if err := writeText(related, "text/html; charset=utf-8", m.HTML); err != nil {
+		return err
+	}
+	for _, image := range m.Images {
+		part, err := related.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {image.Type},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-ID":                {"<" + image.ContentID + ">"},
+			"Content-Disposition":       {mime.FormatMediaType("inline", map[string]string{"filename": image.Filename})},
+		})
+		if err != nil {
+			return err
+		}
+		if err := writeBase64(part, image.Data); err != nil {
+			return err
+		}
+	}
+	if err := related.Close(); err != nil {
+		return err
+	}
+
+	part, err := alternative.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {`multipart/related; type="text/html"; boundary=` + related.Boundary()},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(b.Bytes())
+	return err
+}
+
+// writeText adds a quoted-printable text part
+func writeText(w *multipart.Writer, contentType, text string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := io.WriteString(qp, text); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeBase64 writes data as base64 in lines of 76 characters
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(76, len(encoded))
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// messageID returns a unique Message-ID in the sender's domain
+func messageID(from string, date time.Time) string {
+	domain := "nwcli.local"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = strings.Trim(from[at+1:], "> ")
+	}
+
+	random := make([]byte, 8)
+	rand.Read(random)
+	return fmt.Sprintf("<%d.%x@%s>", date.UnixNano(), random, domain)
+}
@@ -0,0 +1,192 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// part is a decoded MIME part, with its children when it is multipart
+type part struct {
+	contentType string
+	params      map[string]string
+	header      map[string][]string
+	body        string
+	parts       []part
+}
+
+// parseMessage parses the output of WriteTo into its MIME tree
+func parseMessage(t *testing.T, m Message) (*mail.Message, part) {
+	t.Helper()
+
+	var b bytes.Buffer
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	msg, err := mail.ReadMessage(&b)
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	return msg, parsePart(t, msg.Header, msg.Body)
+}
+
+func parsePart(t *testing.T, header map[string][]string, body io.Reader) part {
+	t.Helper()
+
+	contentType := ""
+	if values := header["Content-Type"]; len(values) > 0 {
+		contentType = values[0]
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("Content-Type %q: %v", contentType, err)
+	}
+	p := part{contentType: mediaType, params: params, header: header}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatalf("read %s: %v", mediaType, err)
+		}
+		p.body = string(data)
+		return p
+	}
+
+	r := multipart.NewReader(body, params["boundary"])
+	for {
+		child, err := r.NextPart()
+		if err == io.EOF {
+			return p
+		}
+		if err != nil {
+			t.Fatalf("next part of %s: %v", mediaType, err)
+		}
+		p.parts = append(p.parts, parsePart(t, child.Header, child))
+	}
+}
+
+// types lists the media types of the children of p
+func (p part) types() []string {
+	var types []string
+	for _, child := range p.parts {
+		types = append(types, child.contentType)
+	}
+	return types
+}
+
+func TestMessageHeaders(t *testing.T) {
+	date := time.Date(2026, 10, 18, 7, 30, 0, 0, time.UTC)
+	msg, _ := parseMessage(t, Message{
+		From:    "NWCLI <digest@example.com>",
+		To:      []string{"me@example.com", "Partner <partner@example.com>"},
+		Subject: "📰 Nieuws van vandaag",
+		Date:    date,
+		HTML:    "<p>x</p>",
+		Text:    "x",
+	})
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "📰 Nieuws van vandaag" {
+		t.Errorf("Subject = %q, %v", subject, err)
+	}
+	to, err := msg.Header.AddressList("To")
+	if err != nil || len(to) != 2 || to[1].Address != "partner@example.com" {
+		t.Errorf("To = %v, %v", to, err)
+	}
+	if got, err := msg.Header.Date(); err != nil || !got.Equal(date) {
+		t.Errorf("Date = %v, %v", got, err)
+	}
+	if id := msg.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+		t.Errorf("Message-ID = %q, want the sender's domain", id)
+	}
+	if v := msg.Header.Get("MIME-Version"); v != "1.0" {
+		t.Errorf("MIME-Version = %q", v)
+	}
+}
+
+func TestMessageStructure(t *testing.T) {
+	pixel := []byte("\x89PNG\r\n\x1a\nnot really a png, but bytes all the same")
+	html := `<p>Café — "quoted" <img src="cid:img1@nwcli"></p>`
+	text := "Café — a line that is long enough to need a soft line break in quoted-printable encoding"
+
+	tests := []struct {
+		name   string
+		images []Image
+		want   []string
+	}{
+		{name: "without images", want: []string{"text/plain", "text/html"}},
+		{
+			name:   "with images",
+			images: []Image{{ContentID: "img1@nwcli", Type: "image/png", Filename: "photo 1.png", Data: pixel}},
+			want:   []string{"text/plain", "multipart/related"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, root := parseMessage(t, Message{
+				From:    "digest@example.com",
+				To:      []string{"me@example.com"},
+				Subject: "News",
+				HTML:    html,
+				Text:    text,
+				Images:  tt.images,
+			})
+
+			if root.contentType != "multipart/alternative" {
+				t.Fatalf("root is %s, want multipart/alternative", root.contentType)
+			}
+			if got := root.types(); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("alternative parts = %v, want %v", got, tt.want)
+			}
+
+			plain := root.parts[0]
+			if plain.params["charset"] != "utf-8" || plain.body != text {
+				t.Errorf("text part = %q (charset %q)", plain.body, plain.params["charset"])
+			}
+
+			htmlPart := root.parts[1]
+			if len(tt.images) > 0 {
+				related := root.parts[1]
+				if related.params["type"] != "text/html" {
+					t.Errorf("related type = %q, want text/html", related.params["type"])
+				}
+				if got := related.types(); strings.Join(got, ",") != "text/html,image/png" {
+					t.Fatalf("related parts = %v", got)
+				}
+				htmlPart = related.parts[0]
+
+				image := related.parts[1]
+				if id := image.header["Content-Id"]; len(id) != 1 || id[0] != "<img1@nwcli>" {
+					t.Errorf("Content-ID = %v", id)
+				}
+				disposition, params, err := mime.ParseMediaType(image.header["Content-Disposition"][0])
+				if err != nil || disposition != "inline" || params["filename"] != "photo 1.png" {
+					t.Errorf("Content-Disposition = %q %v, %v", disposition, params, err)
+				}
+				if enc := image.header["Content-Transfer-Encoding"]; len(enc) != 1 || enc[0] != "base64" {
+					t.Errorf("Content-Transfer-Encoding = %v", enc)
+				}
+				for _, line := range strings.Split(strings.TrimRight(image.body, "\r\n"), "\r\n") {
+					if len(line) > 76 {
+						t.Errorf("base64 line of %d characters", len(line))
+					}
+				}
+				data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(image.body, "\r\n", ""))
+				if err != nil || !bytes.Equal(data, pixel) {
+					t.Errorf("image data = %q, %v", data, err)
+				}
+			}
+
+			if htmlPart.contentType != "text/html" || htmlPart.body != html {
+				t.Errorf("html part = %s %q", htmlPart.contentType, htmlPart.body)
+			}
+		})
+	}
+}
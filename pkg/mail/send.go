@@ -0,0 +1,217 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connection security
+const (
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+// Authentication mechanisms
+const (
+	AuthPlain = "plain"
+	AuthLogin = "login"
+	AuthNone  = "none"
+)
+
+// dialTimeout bounds connecting to the SMTP server
+const dialTimeout = 30 * time.Second
+
+// rootCAs verifies the server certificate; nil uses the system roots
+var rootCAs *x509.CertPool
+
+// tlsConfig returns the TLS settings for connecting to host
+func tlsConfig(host string) *tls.Config {
+	return &tls.Config{ServerName: host, RootCAs: rootCAs}
+}
+
+// Options configures the SMTP connection
+type Options struct {
+	Host string
+	// Port defaults to 465 with TLS and 587 otherwise
+	Port int
+	// Security is SecurityStartTLS, SecurityTLS or SecurityNone; empty
+	// means SecurityStartTLS
+	Security string
+	// Auth is AuthPlain, AuthLogin or AuthNone; empty means AuthPlain, or
+	// no authentication without a username
+	Auth     string
+	Username string
+	Password string
+}
+
+// Validate checks the options and the message's addresses
+func (o Options) Validate(msg Message) error {
+	if o.Host == "" {
+		return fmt.Errorf("no SMTP host configured (set mail.host in the config file)")
+	}
+	switch o.Security {
+	case "", SecurityStartTLS, SecurityTLS, SecurityNone:
+	default:
+		return fmt.Errorf("unknown mail security %q (available: %s, %s, %s)", o.Security, SecurityStartTLS, SecurityTLS, SecurityNone)
+	}
+	switch o.Auth {
+	case "", AuthPlain, AuthLogin, AuthNone:
+	default:
+		return fmt.Errorf("unknown mail auth %q (available: %s, %s, %s)", o.Auth, AuthPlain, AuthLogin, AuthNone)
+	}
+	return validateAddresses(msg)
+}
+
+// validateAddresses checks the sender and recipients of a message
+func validateAddresses(msg Message) error {
+	if _, err := netmail.ParseAddress(msg.From); err != nil {
+		return fmt.Errorf("invalid sender %q: %w", msg.From, err)
+	}
+	if len(msg.To) == 0 {
+		return fmt.Errorf("no recipients configured (set mail.to in the config file or use --to)")
+	}
+	for _, to := range msg.To {
+		if _, err := netmail.ParseAddress(to); err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// Send delivers msg to its recipients through the SMTP server
+func Send(ctx context.Context, opts Options, msg Message) error {
+	if err := opts.Validate(msg); err != nil {
+		return err
+	}
+
+	port := opts.Port
+	if port == 0 {
+		port = 587
+		if opts.Security == SecurityTLS {
+			port = 465
+		}
+	}
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(port))
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if opts.Security == SecurityTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: tlsConfig(opts.Host)}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, opts.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if opts.Security == "" || opts.Security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not support STARTTLS (set mail.security to tls or none)", opts.Host)
+		}
+		if err := client.StartTLS(tlsConfig(opts.Host)); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	if auth := opts.auth(); auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	from, _ := netmail.ParseAddress(msg.From)
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("server rejected sender %s: %w", from.Address, err)
+	}
+	for _, to := range msg.To {
+		rcpt, _ := netmail.ParseAddress(to)
+		if err := client.Rcpt(rcpt.Address); err != nil {
+			return fmt.Errorf("server rejected recipient %s: %w", rcpt.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if _, err := msg.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// auth returns the authentication mechanism, or nil for none
+func (o Options) auth() smtp.Auth {
+	switch {
+	case o.Auth == AuthNone, o.Auth == "" && o.Username == "":
+		return nil
+	case o.Auth == AuthLogin:
+		return loginAuth{host: o.Host, username: o.Username, password: o.Password}
+	default:
+		return smtp.PlainAuth("", o.Username, o.Password, o.Host)
+	}
+}
+
+// loginAuth implements the LOGIN mechanism, which some servers offer
+// instead of PLAIN. Like smtp.PlainAuth it refuses to send credentials
+// over unencrypted connections to other hosts than localhost.
+type loginAuth struct {
+	host     string
+	username string
+	password string
+}
+
+func (a loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch prompt := strings.ToLower(strings.TrimSpace(string(fromServer))); prompt {
+	case "username:":
+		return []byte(a.username), nil
+	case "password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN prompt %q", prompt)
+	}
+}
+
+// isLocalhost reports whether host is the local machine
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
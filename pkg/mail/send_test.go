@@ -0,0 +1,402 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer is a loopback SMTP server that records what clients send
+type fakeServer struct {
+	listener net.Listener
+	tls      *tls.Config
+	// startTLS offers STARTTLS; implicitTLS wraps the connection in TLS
+	// from the start
+	startTLS    bool
+	implicitTLS bool
+	username    string
+	password    string
+
+	mu       sync.Mutex
+	usedTLS  bool
+	mechs    []string
+	authOK   bool
+	from     string
+	rcpts    []string
+	data     string
+	failures []string
+}
+
+// newFakeServer starts a fake server with a self-signed certificate for
+// 127.0.0.1 and makes Send trust it for the duration of the test
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	cert, pool := selfSignedCert(t)
+	previous := rootCAs
+	rootCAs = pool
+	t.Cleanup(func() { rootCAs = previous })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	return &fakeServer{
+		listener: listener,
+		tls:      &tls.Config{Certificates: []tls.Certificate{cert}},
+		username: "reader",
+		password: "s3cret",
+	}
+}
+
+// serve handles a single client connection in the background
+func (s *fakeServer) serve() {
+	go func() {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		if s.implicitTLS {
+			conn = tls.Server(conn, s.tls)
+			s.mu.Lock()
+			s.usedTLS = true
+			s.mu.Unlock()
+		}
+		s.session(conn)
+	}()
+}
+
+// options returns Send options pointing at the server
+func (s *fakeServer) options(security, auth string) Options {
+	return Options{
+		Host:     "127.0.0.1",
+		Port:     s.listener.Addr().(*net.TCPAddr).Port,
+		Security: security,
+		Auth:     auth,
+		Username: s.username,
+		Password: s.password,
+	}
+}
+
+// fail records a protocol violation
+func (s *fakeServer) fail(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, fmt.Sprintf(format, args...))
+}
+
+func (s *fakeServer) session(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	r := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		fmt.Fprint(conn, strings.Join(lines, "\r\n")+"\r\n")
+	}
+	readLine := func() (string, bool) {
+		line, err := r.ReadString('\n')
+		return strings.TrimRight(line, "\r\n"), err == nil
+	}
+
+	secure := s.implicitTLS
+	reply("220 127.0.0.1 fake ESMTP")
+	for {
+		line, ok := readLine()
+		if !ok {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			lines := []string{"250-127.0.0.1"}
+			if s.startTLS && !secure {
+				lines = append(lines, "250-STARTTLS")
+			}
+			lines = append(lines, "250-AUTH PLAIN LOGIN", "250 8BITMIME")
+			reply(lines...)
+
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				s.fail("TLS handshake: %v", err)
+				return
+			}
+			conn, r, secure = tlsConn, bufio.NewReader(tlsConn), true
+			s.mu.Lock()
+			s.usedTLS = true
+			s.mu.Unlock()
+
+		case "AUTH":
+			mech, initial, _ := strings.Cut(arg, " ")
+			s.mu.Lock()
+			s.mechs = append(s.mechs, strings.ToUpper(mech))
+			s.mu.Unlock()
+
+			var username, password string
+			switch strings.ToUpper(mech) {
+			case "PLAIN":
+				decoded, _ := base64.StdEncoding.DecodeString(initial)
+				parts := strings.Split(string(decoded), "\x00")
+				if len(parts) == 3 {
+					username, password = parts[1], parts[2]
+				}
+			case "LOGIN":
+				reply("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+				line, _ := readLine()
+				decoded, _ := base64.StdEncoding.DecodeString(line)
+				username = string(decoded)
+				reply("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+				line, _ = readLine()
+				decoded, _ = base64.StdEncoding.DecodeString(line)
+				password = string(decoded)
+			}
+			if username != s.username || password != s.password {
+				reply("535 authentication failed")
+				continue
+			}
+			s.mu.Lock()
+			s.authOK = true
+			s.mu.Unlock()
+			reply("235 authenticated")
+
+		case "MAIL":
+			s.mu.Lock()
+			s.from = address(arg, "FROM:")
+			s.mu.Unlock()
+			reply("250 OK")
+
+		case "RCPT":
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, address(arg, "TO:"))
+			s.mu.Unlock()
+			reply("250 OK")
+
+		case "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				line, ok := readLine()
+				if !ok || line == "." {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, ".") + "\r\n")
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			reply("250 queued")
+
+		case "QUIT":
+			reply("221 bye")
+			return
+
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+// address extracts the mailbox from a MAIL or RCPT argument, dropping
+// parameters like BODY=8BITMIME
+func address(arg, prefix string) string {
+	path, _, _ := strings.Cut(strings.TrimPrefix(arg, prefix), " ")
+	return strings.Trim(path, "<>")
+}
+
+// selfSignedCert returns a certificate for 127.0.0.1 and a pool trusting it
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func testMessage() Message {
+	return Message{
+		From:    "NWCLI <digest@example.com>",
+		To:      []string{"me@example.com", "Partner <partner@example.com>"},
+		Subject: "📰 NL news",
+		HTML:    "<h1>News</h1>",
+		Text:    "News",
+	}
+}
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name        string
+		security    string
+		auth        string
+		startTLS    bool
+		implicitTLS bool
+		wantTLS     bool
+		wantMech    string
+	}{
+		{name: "starttls with plain", security: SecurityStartTLS, auth: AuthPlain, startTLS: true, wantTLS: true, wantMech: "PLAIN"},
+		{name: "starttls with login", security: SecurityStartTLS, auth: AuthLogin, startTLS: true, wantTLS: true, wantMech: "LOGIN"},
+		{name: "default security is starttls", startTLS: true, wantTLS: true, wantMech: "PLAIN"},
+		{name: "implicit tls with login", security: SecurityTLS, auth: AuthLogin, implicitTLS: true, wantTLS: true, wantMech: "LOGIN"},
+		{name: "no security with plain on localhost", security: SecurityNone, auth: AuthPlain, wantMech: "PLAIN"},
+		{name: "no security with login on localhost", security: SecurityNone, auth: AuthLogin, wantMech: "LOGIN"},
+		{name: "no security without auth", security: SecurityNone, auth: AuthNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t)
+			server.startTLS = tt.startTLS
+			server.implicitTLS = tt.implicitTLS
+			server.serve()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := Send(ctx, server.options(tt.security, tt.auth), testMessage()); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.failures) > 0 {
+				t.Fatalf("server: %v", server.failures)
+			}
+			if server.usedTLS != tt.wantTLS {
+				t.Errorf("TLS = %v, want %v", server.usedTLS, tt.wantTLS)
+			}
+			if tt.wantMech == "" {
+				if len(server.mechs) > 0 {
+					t.Errorf("authenticated with %v, want no authentication", server.mechs)
+				}
+			} else {
+				if len(server.mechs) != 1 || server.mechs[0] != tt.wantMech {
+					t.Errorf("auth mechanisms = %v, want [%s]", server.mechs, tt.wantMech)
+				}
+				if !server.authOK {
+					t.Errorf("credentials were not accepted")
+				}
+			}
+			if server.from != "digest@example.com" {
+				t.Errorf("MAIL FROM = %q", server.from)
+			}
+			if want := []string{"me@example.com", "partner@example.com"}; strings.Join(server.rcpts, ",") != strings.Join(want, ",") {
+				t.Errorf("RCPT TO = %v, want %v", server.rcpts, want)
+			}
+			if !strings.Contains(server.data, "Content-Type: multipart/alternative") {
+				t.Errorf("message was not delivered:\n%s", server.data)
+			}
+		})
+	}
+}
+
+func TestSendRequiresStartTLS(t *testing.T) {
+	server := newFakeServer(t)
+	server.serve()
+
+	err := Send(context.Background(), server.options(SecurityStartTLS, AuthPlain), testMessage())
+	if err == nil || !strings.Contains(err.Error(), "does not support STARTTLS") {
+		t.Fatalf("Send = %v, want a STARTTLS error", err)
+	}
+}
+
+func TestSendRejectsWrongPassword(t *testing.T) {
+	for _, auth := range []string{AuthPlain, AuthLogin} {
+		t.Run(auth, func(t *testing.T) {
+			server := newFakeServer(t)
+			server.startTLS = true
+			server.serve()
+
+			opts := server.options(SecurityStartTLS, auth)
+			opts.Password = "wrong"
+			err := Send(context.Background(), opts, testMessage())
+			if err == nil || !strings.Contains(err.Error(), "authentication failed") {
+				t.Fatalf("Send = %v, want an authentication error", err)
+			}
+		})
+	}
+}
+
+func TestLoginAuth(t *testing.T) {
+	auth := loginAuth{host: "mail.example.com", username: "reader", password: "s3cret"}
+
+	tests := []struct {
+		name    string
+		server  smtp.ServerInfo
+		wantErr string
+	}{
+		{name: "tls", server: smtp.ServerInfo{Name: "mail.example.com", TLS: true}},
+		{name: "unencrypted localhost", server: smtp.ServerInfo{Name: "localhost"}, wantErr: "wrong host name"},
+		{name: "unencrypted remote", server: smtp.ServerInfo{Name: "mail.example.com"}, wantErr: "unencrypted connection"},
+		{name: "other host", server: smtp.ServerInfo{Name: "evil.example.com", TLS: true}, wantErr: "wrong host name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mech, initial, err := auth.Start(&tt.server)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Start = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || mech != "LOGIN" || initial != nil {
+				t.Fatalf("Start = %q, %q, %v", mech, initial, err)
+			}
+		})
+	}
+
+	steps := []struct {
+		prompt string
+		more   bool
+		want   string
+	}{
+		{"Username:", true, "reader"},
+		{"password:", true, "s3cret"},
+		{"", false, ""},
+	}
+	for _, step := range steps {
+		got, err := auth.Next([]byte(step.prompt), step.more)
+		if err != nil || string(got) != step.want {
+			t.Errorf("Next(%q) = %q, %v, want %q", step.prompt, got, err, step.want)
+		}
+	}
+	if _, err := auth.Next([]byte("Realm:"), true); err == nil {
+		t.Errorf("Next accepted an unknown prompt")
+	}
+}
@@ -1,6 +1,7 @@
 package news
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -20,8 +21,7 @@ type ArticleCache struct {
 
 // NewArticleCache creates a new article cache
 func NewArticleCache() *ArticleCache {
-	homeDir, _ := os.UserHomeDir()
-	cacheDir := filepath.Join(homeDir, ".nwcli", "cache")
+	cacheDir := defaultCacheDir()
 
 	// Create cache directory if it doesn't exist
 	os.MkdirAll(cacheDir, 0755)
@@ -36,22 +36,111 @@ func NewArticleCache() *ArticleCache {
 	return cache
 }
 
-// StoreArticles stores articles in cache
+// defaultCacheDir returns the directory of the article cache
+func defaultCacheDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".nwcli", "cache")
+}
+
+// EachCachedArticle calls fn for every cached article in cache order. It
+// decodes the cache file one article at a time, so large caches are never
+// held in memory, and stops at the first error fn returns.
+func EachCachedArticle(fn func(Article) error) error {
+	f, err := os.Open(filepath.Join(defaultCacheDir(), "articles.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read cache: %w", err)
+		}
+		if key != "articles" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fmt.Errorf("failed to read cache: %w", err)
+			}
+			continue
+		}
+
+		// A cleared cache stores null instead of an empty list
+		token, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read cache: %w", err)
+		}
+		if token == nil {
+			continue
+		}
+		if token != json.Delim('[') {
+			return fmt.Errorf("failed to read cache: expected [, got %v", token)
+		}
+		for dec.More() {
+			var article Article
+			if err := dec.Decode(&article); err != nil {
+				return fmt.Errorf("failed to read cache: %w", err)
+			}
+			if err := fn(article); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectDelim reads the next JSON token, which must be delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to read cache: expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+// StoreArticles stores articles in cache. Articles that are already cached,
+// by GUID or link, are combined with their cached copy through MergeArticle.
 func (ac *ArticleCache) StoreArticles(articles []Article) {
 	ac.mu.Lock()
 	defer ac.mu.Unlock()
 
-	// Merge with existing articles, avoiding duplicates
-	existingMap := make(map[string]bool)
-	for _, article := range ac.articles {
-		existingMap[article.Link] = true
+	index := make(map[string]int, len(ac.articles))
+	for i, article := range ac.articles {
+		index[article.Key()] = i
+		if article.Link != "" {
+			index[article.Link] = i
+		}
 	}
 
 	for _, article := range articles {
-		if !existingMap[article.Link] {
-			ac.articles = append(ac.articles, article)
-			existingMap[article.Link] = true
+		i, ok := index[article.Key()]
+		if !ok && article.Link != "" {
+			i, ok = index[article.Link]
 		}
+		if ok {
+			// The fetched copy wins a tie with the cached one
+			ac.articles[i] = MergeArticle(article, ac.articles[i])
+			continue
+		}
+
+		index[article.Key()] = len(ac.articles)
+		if article.Link != "" {
+			index[article.Link] = len(ac.articles)
+		}
+		ac.articles = append(ac.articles, article)
 	}
 
 	ac.lastUpdate = time.Now()
@@ -60,18 +149,134 @@ func (ac *ArticleCache) StoreArticles(articles []Article) {
 	ac.saveToDisk()
 }
 
+// UpdateArticle replaces the cached copy of an article, adding it when it
+// is not cached yet
+func (ac *ArticleCache) UpdateArticle(article Article) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	replaced := false
+	for i, cached := range ac.articles {
+		if cached.Key() == article.Key() {
+			ac.articles[i] = article
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		ac.articles = append(ac.articles, article)
+	}
+
+	ac.saveToDisk()
+}
+
+// MergeResult counts what a merge changed
+type MergeResult struct {
+	Added   int
+	Updated int
+}
+
+// Merge adds articles from another cache, such as an imported archive or
+// another device, combining copies of the same article with MergeArticle
+func (ac *ArticleCache) Merge(articles []Article) MergeResult {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	index := make(map[string]int, len(ac.articles))
+	for i, article := range ac.articles {
+		index[article.Key()] = i
+	}
+
+	var result MergeResult
+	for _, article := range articles {
+		i, ok := index[article.Key()]
+		if !ok {
+			index[article.Key()] = len(ac.articles)
+			ac.articles = append(ac.articles, article)
+			result.Added++
+			continue
+		}
+
+		merged := MergeArticle(ac.articles[i], article)
+		if !sameArticle(merged, ac.articles[i]) {
+			ac.articles[i] = merged
+			result.Updated++
+		}
+	}
+
+	if result.Added > 0 || result.Updated > 0 {
+		ac.saveToDisk()
+	}
+	return result
+}
+
+// MergeArticle combines two copies of the same article. The newer copy
+// wins, by update or else publication time, and the categories of both are
+// kept. Text extracted from the article's page is kept when the newer copy
+// only has the feed content.
+func MergeArticle(a, b Article) Article {
+	newer, older := a, b
+	if version(b).After(version(a)) || version(b).Equal(version(a)) && b.FullContent && !a.FullContent {
+		newer, older = b, a
+	}
+
+	merged := newer
+	merged.Categories = unionStrings(newer.Categories, older.Categories)
+	if !merged.FullContent && older.FullContent {
+		merged.Content = older.Content
+		merged.FullContent = true
+	}
+	if merged.Summary == "" {
+		merged.Summary = older.Summary
+	}
+	return merged
+}
+
+// sameArticle reports whether two articles are stored identically. Times
+// decoded from JSON differ from their originals in location only, so the
+// articles are compared as JSON.
+func sameArticle(a, b Article) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
+// version returns the time an article was last changed
+func version(a Article) time.Time {
+	if !a.Updated.IsZero() {
+		return a.Updated
+	}
+	return a.Published
+}
+
+// unionStrings returns a followed by the strings of b it lacks, ignoring
+// case
+func unionStrings(a, b []string) []string {
+	union := a
+	for _, s := range b {
+		found := false
+		for _, existing := range union {
+			if strings.EqualFold(existing, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			union = append(union[:len(union):len(union)], s)
+		}
+	}
+	return union
+}
+
 // SearchArticles searches cached articles
 func (ac *ArticleCache) SearchArticles(query string, limit int) []Article {
 	ac.mu.RLock()
 	defer ac.mu.RUnlock()
 
 	var matches []Article
-	query = strings.ToLower(query)
 
 	for _, article := range ac.articles {
-		if strings.Contains(strings.ToLower(article.Title), query) ||
-			strings.Contains(strings.ToLower(article.Description), query) ||
-			strings.Contains(strings.ToLower(article.Content), query) {
+		if article.Matches(query) {
 			matches = append(matches, article)
 		}
 
@@ -83,24 +288,61 @@ func (ac *ArticleCache) SearchArticles(query string, limit int) []Article {
 	return matches
 }
 
-// GetCachedArticles returns all cached articles
+// GetCachedArticles returns a copy of the cached articles, so callers can
+// use it while background fetches add to the cache
 func (ac *ArticleCache) GetCachedArticles(limit int) []Article {
 	ac.mu.RLock()
 	defer ac.mu.RUnlock()
 
-	if limit > 0 && len(ac.articles) > limit {
-		return ac.articles[:limit]
+	articles := ac.articles
+	if limit > 0 && len(articles) > limit {
+		articles = articles[:limit]
 	}
 
-	return ac.articles
+	return append([]Article(nil), articles...)
 }
 
-// IsStale checks if cache is stale (older than 1 hour)
-func (ac *ArticleCache) IsStale() bool {
+// FindByID returns the cached article whose ID starts with the given prefix
+func (ac *ArticleCache) FindByID(id string) (Article, error) {
 	ac.mu.RLock()
 	defer ac.mu.RUnlock()
 
-	return time.Since(ac.lastUpdate) > time.Hour
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return Article{}, fmt.Errorf("empty article id")
+	}
+
+	var matches []Article
+	for _, article := range ac.articles {
+		if strings.HasPrefix(article.ID(), id) {
+			matches = append(matches, article)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Article{}, fmt.Errorf("no cached article with id %q", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return Article{}, fmt.Errorf("article id %q is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// DiskSize returns the number of bytes the cache takes on disk
+func (ac *ArticleCache) DiskSize() int64 {
+	entries, err := os.ReadDir(ac.cacheDir)
+	if err != nil {
+		return 0
+	}
+
+	var size int64
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() {
+			size += info.Size()
+		}
+	}
+	return size
 }
 
 // Clear clears the cache
@@ -119,23 +361,40 @@ func (ac *ArticleCache) Clear() {
 func (ac *ArticleCache) saveToDisk() {
 	cacheFile := filepath.Join(ac.cacheDir, "articles.json")
 
-	data := map[string]interface{}{
-		"articles":    ac.articles,
-		"last_update": ac.lastUpdate,
+	data := cacheData{
+		Articles:   ac.articles,
+		LastUpdate: ac.lastUpdate,
 	}
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
-		fmt.Printf("Warning: Failed to marshal cache data: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: Failed to marshal cache data: %v\n", err)
 		return
 	}
 
-	err = os.WriteFile(cacheFile, jsonData, 0644)
+	err = writeFileAtomic(cacheFile, jsonData, 0644)
 	if err != nil {
-		fmt.Printf("Warning: Failed to save cache to disk: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: Failed to save cache to disk: %v\n", err)
 	}
 }
 
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so a process exiting during a background fetch never
+// leaves a truncated file behind
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// cacheData is the on-disk layout of articles.json
+type cacheData struct {
+	Articles   []Article `json:"articles"`
+	LastUpdate time.Time `json:"last_update"`
+}
+
 // loadFromDisk loads articles from disk
 func (ac *ArticleCache) loadFromDisk() {
 	cacheFile := filepath.Join(ac.cacheDir, "articles.json")
@@ -146,65 +405,13 @@ func (ac *ArticleCache) loadFromDisk() {
 		return
 	}
 
-	var cacheData map[string]interface{}
-	err = json.Unmarshal(data, &cacheData)
+	var stored cacheData
+	err = json.Unmarshal(data, &stored)
 	if err != nil {
-		fmt.Printf("Warning: Failed to unmarshal cache data: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: Failed to unmarshal cache data: %v\n", err)
 		return
 	}
 
-	// Parse articles
-	if articlesData, ok := cacheData["articles"].([]interface{}); ok {
-		for _, articleData := range articlesData {
-			if articleMap, ok := articleData.(map[string]interface{}); ok {
-				article := parseArticleFromMap(articleMap)
-				ac.articles = append(ac.articles, article)
-			}
-		}
-	}
-
-	// Parse last update time
-	if lastUpdateStr, ok := cacheData["last_update"].(string); ok {
-		if parsed, err := time.Parse(time.RFC3339, lastUpdateStr); err == nil {
-			ac.lastUpdate = parsed
-		}
-	}
-}
-
-// parseArticleFromMap parses an article from a map
-func parseArticleFromMap(data map[string]interface{}) Article {
-	article := Article{}
-
-	if title, ok := data["title"].(string); ok {
-		article.Title = title
-	}
-	if desc, ok := data["description"].(string); ok {
-		article.Description = desc
-	}
-	if content, ok := data["content"].(string); ok {
-		article.Content = content
-	}
-	if link, ok := data["link"].(string); ok {
-		article.Link = link
-	}
-	if source, ok := data["source"].(string); ok {
-		article.Source = source
-	}
-	if imageURL, ok := data["image_url"].(string); ok {
-		article.ImageURL = imageURL
-	}
-	if publishedStr, ok := data["published"].(string); ok {
-		if parsed, err := time.Parse(time.RFC3339, publishedStr); err == nil {
-			article.Published = parsed
-		}
-	}
-	if categoriesData, ok := data["categories"].([]interface{}); ok {
-		for _, cat := range categoriesData {
-			if catStr, ok := cat.(string); ok {
-				article.Categories = append(article.Categories, catStr)
-			}
-		}
-	}
-
-	return article
+	ac.articles = stored.Articles
+	ac.lastUpdate = stored.LastUpdate
 }
@@ -0,0 +1,178 @@
+package news
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeArticle(t *testing.T) {
+	earlier := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		a, b  Article
+		check func(t *testing.T, merged Article)
+	}{
+		{
+			name: "newer update wins",
+			a:    Article{GUID: "1", Title: "Old title", Published: earlier, Updated: earlier},
+			b:    Article{GUID: "1", Title: "New title", Published: earlier, Updated: later},
+			check: func(t *testing.T, merged Article) {
+				if merged.Title != "New title" || !merged.Updated.Equal(later) {
+					t.Errorf("merged = %q updated %v, want the newer copy", merged.Title, merged.Updated)
+				}
+			},
+		},
+		{
+			name: "publication time without updates",
+			a:    Article{GUID: "1", Title: "Later", Published: later},
+			b:    Article{GUID: "1", Title: "Earlier", Published: earlier},
+			check: func(t *testing.T, merged Article) {
+				if merged.Title != "Later" {
+					t.Errorf("merged title = %q, want Later", merged.Title)
+				}
+			},
+		},
+		{
+			name: "categories of both are kept",
+			a:    Article{GUID: "1", Published: later, Categories: []string{"Politiek"}},
+			b:    Article{GUID: "1", Published: earlier, Categories: []string{"politiek", "Economie"}},
+			check: func(t *testing.T, merged Article) {
+				if want := []string{"Politiek", "Economie"}; !reflect.DeepEqual(merged.Categories, want) {
+					t.Errorf("categories = %v, want %v", merged.Categories, want)
+				}
+			},
+		},
+		{
+			name: "full content survives a newer feed copy",
+			a:    Article{GUID: "1", Title: "Edited", Published: later, Content: "feed text"},
+			b:    Article{GUID: "1", Title: "Original", Published: earlier, Content: "page text", FullContent: true},
+			check: func(t *testing.T, merged Article) {
+				if merged.Title != "Edited" || merged.Content != "page text" || !merged.FullContent {
+					t.Errorf("merged = %q %q full %v, want the edited title with the page text", merged.Title, merged.Content, merged.FullContent)
+				}
+			},
+		},
+		{
+			name: "full content wins a tie",
+			a:    Article{GUID: "1", Published: earlier, Content: "feed text"},
+			b:    Article{GUID: "1", Published: earlier, Content: "page text", FullContent: true},
+			check: func(t *testing.T, merged Article) {
+				if merged.Content != "page text" || !merged.FullContent {
+					t.Errorf("merged content = %q full %v, want the page text", merged.Content, merged.FullContent)
+				}
+			},
+		},
+		{
+			name: "summary of the older copy is kept",
+			a:    Article{GUID: "1", Published: later},
+			b:    Article{GUID: "1", Published: earlier, Summary: "In short"},
+			check: func(t *testing.T, merged Article) {
+				if merged.Summary != "In short" {
+					t.Errorf("summary = %q, want the older summary", merged.Summary)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, MergeArticle(tt.a, tt.b))
+			t.Run("reversed", func(t *testing.T) {
+				tt.check(t, MergeArticle(tt.b, tt.a))
+			})
+		})
+	}
+}
+
+func TestArticleCacheMerge(t *testing.T) {
+	published := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	cache := &ArticleCache{cacheDir: t.TempDir()}
+	cache.StoreArticles([]Article{
+		{GUID: "kept", Link: "https://example.com/kept", Title: "Kept", Published: published},
+		{GUID: "edited", Link: "https://example.com/edited", Title: "Before", Published: published},
+	})
+
+	result := cache.Merge([]Article{
+		{GUID: "kept", Link: "https://example.com/kept", Title: "Kept", Published: published},
+		{GUID: "edited", Link: "https://example.com/edited", Title: "After", Published: published, Updated: published.Add(time.Hour)},
+		{GUID: "new", Title: "New", Published: published},
+		{Link: "https://example.com/no-guid", Title: "By link", Published: published},
+	})
+	if want := (MergeResult{Added: 2, Updated: 1}); result != want {
+		t.Errorf("Merge = %+v, want %+v", result, want)
+	}
+
+	titles := make(map[string]string)
+	for _, article := range cache.GetCachedArticles(0) {
+		titles[article.Key()] = article.Title
+	}
+	want := map[string]string{
+		"kept":                        "Kept",
+		"edited":                      "After",
+		"new":                         "New",
+		"https://example.com/no-guid": "By link",
+	}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("cache = %v, want %v", titles, want)
+	}
+
+	// Merging the same articles again changes nothing
+	again := cache.Merge(cache.GetCachedArticles(0))
+	if again != (MergeResult{}) {
+		t.Errorf("second Merge = %+v, want no changes", again)
+	}
+
+	// The merge is saved
+	reloaded := &ArticleCache{cacheDir: cache.cacheDir}
+	reloaded.loadFromDisk()
+	if got := len(reloaded.GetCachedArticles(0)); got != len(want) {
+		t.Errorf("reloaded %d articles, want %d", got, len(want))
+	}
+}
+
+func TestStoreArticles(t *testing.T) {
+	published := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cached  Article
+		fetched Article
+		want    Article
+	}{
+		{
+			name:    "refetched teaser is replaced",
+			cached:  Article{GUID: "1", Link: "https://example.com/1", Title: "Title", Published: published, Content: "teaser..."},
+			fetched: Article{GUID: "1", Link: "https://example.com/1", Title: "Title", Published: published, Content: "feed text"},
+			want:    Article{GUID: "1", Link: "https://example.com/1", Title: "Title", Published: published, Content: "feed text"},
+		},
+		{
+			name:    "page text survives a refetch",
+			cached:  Article{GUID: "1", Link: "https://example.com/1", Title: "Title", Published: published, Content: "page text", FullContent: true},
+			fetched: Article{GUID: "1", Link: "https://example.com/1", Title: "Edited", Published: published, Updated: published.Add(time.Hour), Content: "feed text"},
+			want:    Article{GUID: "1", Link: "https://example.com/1", Title: "Edited", Published: published, Updated: published.Add(time.Hour), Content: "page text", FullContent: true},
+		},
+		{
+			name:    "matched by link",
+			cached:  Article{Link: "https://example.com/1", Title: "Title", Published: published, Categories: []string{"Politiek"}},
+			fetched: Article{GUID: "guid-1", Link: "https://example.com/1", Title: "Title", Published: published, Categories: []string{"Economie"}},
+			want:    Article{GUID: "guid-1", Link: "https://example.com/1", Title: "Title", Published: published, Categories: []string{"Economie", "Politiek"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &ArticleCache{cacheDir: t.TempDir()}
+			cache.StoreArticles([]Article{tt.cached})
+			cache.StoreArticles([]Article{tt.fetched})
+
+			got := cache.GetCachedArticles(0)
+			if len(got) != 1 {
+				t.Fatalf("cache has %d articles, want 1", len(got))
+			}
+			if !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("cached = %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxPageSize limits how much of an article page is read
+const maxPageSize = 5 << 20
+
+// skippedElements never contain article text
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+	atom.Form:     true,
+	atom.Button:   true,
+	atom.Figure:   true,
+	atom.Svg:      true,
+	atom.Iframe:   true,
+}
+
+// FetchArticleContent downloads an article's web page and extracts its
+// main text as markdown
+func (rf *RSSFetcher) FetchArticleContent(ctx context.Context, article Article) (string, error) {
+	if article.Link == "" {
+		return "", fmt.Errorf("article has no link")
+	}
+
+	req, err := rf.NewRequest(ctx, article.Source, article.Link)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch article: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := rf.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch article: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch article: HTTP %d", resp.StatusCode)
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse article: %w", err)
+	}
+
+	content := ExtractContent(doc)
+	if content == "" {
+		return "", fmt.Errorf("no article text found on %s", article.Link)
+	}
+
+	return content, nil
+}
+
+// ExtractContent finds the main text of a web page and returns it as
+// markdown. It prefers an <article> or <main> element and otherwise picks
+// the element holding the most paragraph text.
+func ExtractContent(doc *html.Node) string {
+	root := findElement(doc, atom.Article)
+	if root == nil || paragraphText(root) < 200 {
+		if main := findElement(doc, atom.Main); main != nil && paragraphText(main) > 0 {
+			root = main
+		}
+	}
+	if root == nil || paragraphText(root) < 200 {
+		root = densestElement(doc)
+	}
+	if root == nil {
+		return ""
+	}
+
+	var blocks []string
+	collectBlocks(root, &blocks)
+	return strings.Join(blocks, "\n\n")
+}
+
+// findElement returns the first element of the given type
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// densestElement returns the element whose direct <p> children hold the
+// most text
+func densestElement(doc *html.Node) *html.Node {
+	var best *html.Node
+	bestScore := 0
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && skippedElements[n.DataAtom] {
+			return
+		}
+
+		score := 0
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.DataAtom == atom.P {
+				score += len(nodeText(c))
+			}
+		}
+		if score > bestScore {
+			best, bestScore = n, score
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return best
+}
+
+// paragraphText returns the amount of paragraph text below n
+func paragraphText(n *html.Node) int {
+	total := 0
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if skippedElements[n.DataAtom] {
+				return
+			}
+			if n.DataAtom == atom.P {
+				total += len(nodeText(n))
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return total
+}
+
+// collectBlocks appends the text blocks below n as markdown
+func collectBlocks(n *html.Node, blocks *[]string) {
+	if n.Type != html.ElementNode && n.Type != html.DocumentNode {
+		return
+	}
+	if skippedElements[n.DataAtom] {
+		return
+	}
+
+	block := func(prefix string) {
+		if text := nodeText(n); text != "" {
+			*blocks = append(*blocks, prefix+text)
+		}
+	}
+
+	switch n.DataAtom {
+	case atom.P:
+		block("")
+		return
+	case atom.H1, atom.H2:
+		block("## ")
+		return
+	case atom.H3, atom.H4, atom.H5, atom.H6:
+		block("### ")
+		return
+	case atom.Blockquote:
+		block("> ")
+		return
+	case atom.Li:
+		block("- ")
+		return
+	case atom.Pre:
+		if text := nodeText(n); text != "" {
+			*blocks = append(*blocks, "```\n"+text+"\n```")
+		}
+		return
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectBlocks(c, blocks)
+	}
+}
+
+// nodeText returns the whitespace-normalized text below n
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+		case html.ElementNode:
+			if skippedElements[n.DataAtom] {
+				return
+			}
+			if n.DataAtom == atom.Br {
+				b.WriteString(" ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
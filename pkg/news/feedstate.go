@@ -0,0 +1,126 @@
+package news
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FeedState is the fetch history of a source
+type FeedState struct {
+	LastAttempt time.Time `json:"last_attempt,omitzero"`
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	LastError   string    `json:"last_error,omitempty"`
+	// FailureStreak counts the failed fetches since the last success
+	FailureStreak int `json:"failure_streak,omitempty"`
+	// Fetches and Items count the successful fetches and the articles
+	// they returned
+	Fetches int `json:"fetches,omitempty"`
+	Items   int `json:"items,omitempty"`
+	// LastItems is the number of articles the last successful fetch
+	// returned; that many of the newest cached articles stand in for the
+	// feed while it is served from the cache
+	LastItems int `json:"last_items,omitempty"`
+	// TTLMinutes is the refresh interval the feed advertised in its last
+	// successful fetch
+	TTLMinutes int `json:"ttl_minutes,omitempty"`
+}
+
+// FeedTTL returns the refresh interval the feed advertises, or 0
+func (fs FeedState) FeedTTL() time.Duration {
+	return time.Duration(fs.TTLMinutes) * time.Minute
+}
+
+// AverageItems returns the average number of articles per fetch
+func (fs FeedState) AverageItems() float64 {
+	if fs.Fetches == 0 {
+		return 0
+	}
+	return float64(fs.Items) / float64(fs.Fetches)
+}
+
+// feedStateMu serializes updates of the feed state file
+var feedStateMu sync.Mutex
+
+// feedStatePath returns the location of the feed state file
+func feedStatePath() string {
+	return filepath.Join(defaultCacheDir(), "feeds.json")
+}
+
+// LoadFeedStates returns the fetch history of every source fetched so
+// far, by source name
+func LoadFeedStates() map[string]FeedState {
+	feedStateMu.Lock()
+	defer feedStateMu.Unlock()
+	return loadFeedStates()
+}
+
+func loadFeedStates() map[string]FeedState {
+	states := make(map[string]FeedState)
+	if data, err := os.ReadFile(feedStatePath()); err == nil {
+		json.Unmarshal(data, &states)
+	}
+	return states
+}
+
+// saveFeedStates writes the feed state file
+func saveFeedStates(states map[string]FeedState) {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return
+	}
+	os.MkdirAll(defaultCacheDir(), 0755)
+	writeFileAtomic(feedStatePath(), data, 0644)
+}
+
+// recordFetch adds the outcome of fetching a source to its history,
+// including the refresh interval its feed advertised. Fetches refused in
+// offline mode are not recorded.
+func recordFetch(source string, items int, ttl time.Duration, err error) {
+	if errors.Is(err, ErrOffline) {
+		return
+	}
+
+	feedStateMu.Lock()
+	defer feedStateMu.Unlock()
+
+	states := loadFeedStates()
+	state := states[source]
+	state.LastAttempt = time.Now()
+	if err != nil {
+		state.LastError = err.Error()
+		state.FailureStreak++
+	} else {
+		state.LastSuccess = state.LastAttempt
+		state.LastError = ""
+		state.FailureStreak = 0
+		state.Fetches++
+		state.Items += items
+		state.LastItems = items
+		state.TTLMinutes = int(ttl / time.Minute)
+	}
+	states[source] = state
+	saveFeedStates(states)
+}
+
+// MergeFeedStates adds the fetch history of another cache. For each
+// source the history with the latest attempt wins.
+func MergeFeedStates(other map[string]FeedState) {
+	feedStateMu.Lock()
+	defer feedStateMu.Unlock()
+
+	states := loadFeedStates()
+	changed := false
+	for source, state := range other {
+		if state.LastAttempt.After(states[source].LastAttempt) {
+			states[source] = state
+			changed = true
+		}
+	}
+	if changed {
+		saveFeedStates(states)
+	}
+}
@@ -2,55 +2,219 @@ package news
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"nwcli/pkg/textutil"
+
 	"github.com/mmcdole/gofeed"
 )
 
+// DefaultUserAgent is sent with every request unless overridden
+const DefaultUserAgent = "nwcli/1.0 (+https://github.com/DaanHessen/nwcli)"
+
+// ErrOffline is returned when a network fetch is attempted in offline mode
+var ErrOffline = errors.New("offline mode: network access disabled")
+
+// FetcherOptions configures the HTTP behaviour of an RSSFetcher
+type FetcherOptions struct {
+	UserAgent string
+	// Proxy is an http://, https:// or socks5:// URL. Empty means the
+	// proxy is taken from the environment.
+	Proxy string
+	// CACerts lists PEM files with additional trusted root certificates
+	CACerts []string
+	Timeout time.Duration
+	// Headers maps a source name (or "*" for all sources) to extra headers
+	Headers map[string]map[string]string
+	Offline bool
+}
+
 // RSSFetcher handles RSS feed fetching
 type RSSFetcher struct {
-	parser *gofeed.Parser
-	client *http.Client
+	parser  *gofeed.Parser
+	client  *http.Client
+	options FetcherOptions
 }
 
 // NewRSSFetcher creates a new RSS fetcher
 func NewRSSFetcher() *RSSFetcher {
+	fetcher, _ := NewRSSFetcherWithOptions(FetcherOptions{})
+	return fetcher
+}
+
+// NewRSSFetcherWithOptions creates an RSS fetcher with a configured HTTP client
+func NewRSSFetcherWithOptions(opts FetcherOptions) (*RSSFetcher, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", opts.Proxy, err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", proxyURL.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(opts.CACerts) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		for _, file := range opts.CACerts {
+			pem, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate %s: %w", file, err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid certificates found in %s", file)
+			}
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   opts.Timeout,
+		Transport: transport,
 	}
 
 	parser := gofeed.NewParser()
 	parser.Client = client
+	parser.UserAgent = opts.UserAgent
+	parser.RSSTranslator = &rssTranslator{}
+	parser.AtomTranslator = &atomTranslator{}
+	parser.JSONTranslator = &jsonTranslator{}
 
 	return &RSSFetcher{
-		parser: parser,
-		client: client,
+		parser:  parser,
+		client:  client,
+		options: opts,
+	}, nil
+}
+
+// Client returns the HTTP client used by the fetcher
+func (rf *RSSFetcher) Client() *http.Client {
+	return rf.client
+}
+
+// Offline reports whether the fetcher refuses network access
+func (rf *RSSFetcher) Offline() bool {
+	return rf.options.Offline
+}
+
+// NewRequest builds a GET request carrying the configured User-Agent and
+// the extra headers configured for the given source
+func (rf *RSSFetcher) NewRequest(ctx context.Context, sourceName, rawURL string) (*http.Request, error) {
+	if rf.options.Offline {
+		return nil, ErrOffline
 	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", rf.options.UserAgent)
+	for key, value := range rf.options.Headers["*"] {
+		req.Header.Set(key, value)
+	}
+	for name, headers := range rf.options.Headers {
+		if name == "*" || !strings.EqualFold(name, sourceName) {
+			continue
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+	}
+
+	return req, nil
 }
 
 // FetchFromSource fetches articles from a news source
 func (rf *RSSFetcher) FetchFromSource(source Source, fullContent bool) ([]Article, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	articles, _, err := rf.fetchFeed(source, fullContent)
+	return articles, err
+}
+
+// fetchFeed fetches articles from a news source along with the refresh
+// interval its feed advertises
+func (rf *RSSFetcher) fetchFeed(source Source, fullContent bool) ([]Article, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rf.options.Timeout)
 	defer cancel()
 
-	feed, err := rf.parser.ParseURLWithContext(source.URL, ctx)
+	req, err := rf.NewRequest(ctx, source.Name, source.URL)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch RSS feed from %s: %w", source.Name, err)
+	}
+
+	resp, err := rf.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSS feed from %s: %w", source.Name, err)
+		return nil, 0, fmt.Errorf("failed to fetch RSS feed from %s: %w", source.Name, err)
 	}
+	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, 0, fmt.Errorf("failed to fetch RSS feed from %s: HTTP %d", source.Name, resp.StatusCode)
+	}
+
+	feed, err := rf.parser.Parse(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse RSS feed from %s: %w", source.Name, err)
+	}
+
+	return buildArticles(feed, source, fullContent), feedTTL(feed), nil
+}
+
+// buildArticles converts parsed feed items into articles
+func buildArticles(feed *gofeed.Feed, source Source, fullContent bool) []Article {
 	var articles []Article
 
+	language := feed.Language
+	if language == "" {
+		language = source.Language
+	}
+
 	for _, item := range feed.Items {
 		article := Article{
 			Title:       item.Title,
 			Description: item.Description,
 			Content:     extractContent(item, fullContent),
 			Link:        item.Link,
+			GUID:        item.GUID,
 			Source:      source.Name,
+			Authors:     extractAuthors(item, feed),
 			Categories:  item.Categories,
+			Enclosures:  extractEnclosures(item),
+			Media:       extractMedia(item),
+			CommentsURL: item.Custom[customComments],
+			Language:    language,
+			Copyright:   feed.Copyright,
+		}
+
+		if rights := item.Custom[customRights]; rights != "" {
+			article.Copyright = rights
+		}
+		if itemLanguage := item.Custom[customLanguage]; itemLanguage != "" {
+			article.Language = itemLanguage
 		}
 
 		// Parse published date
@@ -62,6 +226,10 @@ func (rf *RSSFetcher) FetchFromSource(source Source, fullContent bool) ([]Articl
 			article.Published = time.Now()
 		}
 
+		if item.UpdatedParsed != nil && !item.UpdatedParsed.Equal(article.Published) {
+			article.Updated = *item.UpdatedParsed
+		}
+
 		// Extract image URL
 		if item.Image != nil && item.Image.URL != "" {
 			article.ImageURL = item.Image.URL
@@ -76,7 +244,50 @@ func (rf *RSSFetcher) FetchFromSource(source Source, fullContent bool) ([]Articl
 		articles = append(articles, article)
 	}
 
-	return articles, nil
+	return articles
+}
+
+// extractAuthors returns the item authors, falling back to the feed authors
+func extractAuthors(item *gofeed.Item, feed *gofeed.Feed) []Author {
+	people := item.Authors
+	if len(people) == 0 && item.DublinCoreExt != nil {
+		for _, creator := range item.DublinCoreExt.Creator {
+			people = append(people, &gofeed.Person{Name: creator})
+		}
+	}
+	if len(people) == 0 {
+		people = feed.Authors
+	}
+
+	var authors []Author
+	for _, person := range people {
+		if person == nil || (person.Name == "" && person.Email == "") {
+			continue
+		}
+		authors = append(authors, Author{
+			Name:  strings.TrimSpace(person.Name),
+			Email: strings.TrimSpace(person.Email),
+		})
+	}
+
+	return authors
+}
+
+// extractEnclosures returns the item enclosures (audio, video, images)
+func extractEnclosures(item *gofeed.Item) []Enclosure {
+	var enclosures []Enclosure
+	for _, enc := range item.Enclosures {
+		if enc == nil || enc.URL == "" {
+			continue
+		}
+		length, _ := strconv.ParseInt(strings.TrimSpace(enc.Length), 10, 64)
+		enclosures = append(enclosures, Enclosure{
+			URL:    enc.URL,
+			Type:   enc.Type,
+			Length: length,
+		})
+	}
+	return enclosures
 }
 
 // extractContent extracts the best available content from feed item
@@ -104,8 +315,8 @@ func extractContent(item *gofeed.Item, fullContent bool) string {
 	if item.Description != "" {
 		content := cleanHTML(item.Description)
 		// For summary mode, limit length
-		if !fullContent && len(content) > 300 {
-			content = content[:297] + "..."
+		if !fullContent {
+			content = textutil.Truncate(content, 300)
 		}
 		return content
 	}
@@ -113,8 +324,8 @@ func extractContent(item *gofeed.Item, fullContent bool) string {
 	// Fallback to content if description is empty
 	if item.Content != "" {
 		content := cleanHTML(item.Content)
-		if !fullContent && len(content) > 300 {
-			content = content[:297] + "..."
+		if !fullContent {
+			content = textutil.Truncate(content, 300)
 		}
 		return content
 	}
@@ -157,12 +368,8 @@ func cleanDescription(desc string) string {
 	// Remove HTML tags
 	cleaned := cleanHTML(desc)
 
-	// Limit length
-	if len(cleaned) > 300 {
-		cleaned = cleaned[:297] + "..."
-	}
-
-	return strings.TrimSpace(cleaned)
+	// Limit length without splitting multi-byte characters
+	return textutil.Truncate(strings.TrimSpace(cleaned), 300)
 }
 
 // cleanHTML removes HTML tags and entities (basic implementation)
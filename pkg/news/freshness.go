@@ -0,0 +1,169 @@
+package news
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Defaults for serving sources from the cache
+const (
+	// DefaultTTL is how long a fetched source stays fresh when neither
+	// the configuration nor the feed says otherwise
+	DefaultTTL = 30 * time.Minute
+	// DefaultStaleWhileRevalidate is how long after expiring a source is
+	// still served from the cache while it is fetched again
+	DefaultStaleWhileRevalidate = 24 * time.Hour
+)
+
+// customTTL carries the feed's <ttl> in minutes from the RSS translator
+const customTTL = "nwcli:ttl"
+
+// Freshness decides when sources are served from the cache instead of
+// being fetched
+type Freshness struct {
+	// TTL applies to sources whose feed does not advertise one; zero
+	// uses DefaultTTL
+	TTL time.Duration
+	// SourceTTL overrides the TTL of sources by name, including the TTL
+	// their feed advertises
+	SourceTTL map[string]time.Duration
+	// StaleWhileRevalidate is how long after expiring a source is still
+	// served from the cache while it is refetched in the background; zero
+	// fetches expired sources before answering
+	StaleWhileRevalidate time.Duration
+	// Refresh fetches every source regardless of its TTL
+	Refresh bool
+}
+
+// SourceState is how usable the cached articles of a source are
+type SourceState int
+
+const (
+	// SourceFresh sources are served from the cache
+	SourceFresh SourceState = iota
+	// SourceStale sources are served from the cache and refetched in the
+	// background
+	SourceStale
+	// SourceExpired sources are fetched before answering
+	SourceExpired
+)
+
+// TTLFor returns how long a source stays fresh after a successful fetch:
+// the configured TTL of the source, else the TTL its feed advertises,
+// else the default
+func (f Freshness) TTLFor(source string, state FeedState) time.Duration {
+	for name, ttl := range f.SourceTTL {
+		if strings.EqualFold(name, source) {
+			return ttl
+		}
+	}
+	if ttl := state.FeedTTL(); ttl > 0 {
+		return ttl
+	}
+	if f.TTL > 0 {
+		return f.TTL
+	}
+	return DefaultTTL
+}
+
+// State returns how usable the cached articles of a source are, given
+// its fetch history
+func (f Freshness) State(source string, state FeedState) SourceState {
+	if f.Refresh || state.LastSuccess.IsZero() {
+		return SourceExpired
+	}
+
+	age := time.Since(state.LastSuccess)
+	ttl := f.TTLFor(source, state)
+	switch {
+	case age < ttl:
+		return SourceFresh
+	case age < ttl+f.StaleWhileRevalidate:
+		return SourceStale
+	default:
+		return SourceExpired
+	}
+}
+
+// feedTTL returns the refresh interval a feed advertises with <ttl> or
+// the syndication module's sy:updatePeriod and sy:updateFrequency, or 0
+func feedTTL(feed *gofeed.Feed) time.Duration {
+	if minutes, err := strconv.Atoi(feed.Custom[customTTL]); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+
+	sy := feed.Extensions["sy"]
+	if sy == nil || len(sy["updatePeriod"]) == 0 {
+		return 0
+	}
+
+	var period time.Duration
+	switch strings.ToLower(strings.TrimSpace(sy["updatePeriod"][0].Value)) {
+	case "hourly":
+		period = time.Hour
+	case "daily":
+		period = 24 * time.Hour
+	case "weekly":
+		period = 7 * 24 * time.Hour
+	case "monthly":
+		period = 30 * 24 * time.Hour
+	case "yearly":
+		period = 365 * 24 * time.Hour
+	default:
+		return 0
+	}
+
+	frequency := 1
+	if values := sy["updateFrequency"]; len(values) > 0 {
+		if n, err := strconv.Atoi(strings.TrimSpace(values[0].Value)); err == nil && n > 0 {
+			frequency = n
+		}
+	}
+	return period / time.Duration(frequency)
+}
+
+// Background refetches of sources served stale
+var (
+	revalidations sync.WaitGroup
+	// revalidationMu guards revalidationDeadline, the time by which every
+	// refetch started so far should have finished
+	revalidationMu       sync.Mutex
+	revalidationDeadline time.Time
+)
+
+// startRevalidation registers a background refetch bounded by timeout
+func startRevalidation(timeout time.Duration) {
+	revalidationMu.Lock()
+	defer revalidationMu.Unlock()
+
+	revalidations.Add(1)
+	if deadline := time.Now().Add(timeout); deadline.After(revalidationDeadline) {
+		revalidationDeadline = deadline
+	}
+}
+
+// WaitForRevalidation blocks until the sources served stale have been
+// refetched, so the process does not exit halfway through, but no longer
+// than the fetch timeout counted from when the last refetch started
+func WaitForRevalidation() {
+	done := make(chan struct{})
+	go func() {
+		revalidations.Wait()
+		close(done)
+	}()
+
+	revalidationMu.Lock()
+	wait := time.Until(revalidationDeadline)
+	revalidationMu.Unlock()
+
+	timer := time.NewTimer(max(wait, 0))
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
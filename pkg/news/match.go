@@ -0,0 +1,241 @@
+package news
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Snippet sizes
+const (
+	// snippetContext is the number of characters shown on each side of a
+	// match
+	snippetContext = 60
+	// maxSnippets caps the snippets of a field
+	maxSnippets = 2
+)
+
+// Span is a range of characters in a text, counted in runes from 0 with
+// the end exclusive
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Snippet is an excerpt of a field around one or more matches
+type Snippet struct {
+	Text string `json:"text"`
+	// Highlights are the matches within Text
+	Highlights []Span `json:"highlights"`
+}
+
+// FieldMatch lists where a query occurs in a field of an article
+type FieldMatch struct {
+	// Field is title, description or content
+	Field string `json:"field"`
+	// Positions are the matches in the field's text, after HTML tags are
+	// removed and whitespace is collapsed
+	Positions []Span `json:"positions"`
+	// Snippets show the matches in context; titles have none since they
+	// are shown whole
+	Snippets []Snippet `json:"snippets,omitempty"`
+}
+
+// SearchResult is an article with the places a search query matched
+type SearchResult struct {
+	Article
+	Matches []FieldMatch `json:"matches"`
+}
+
+// Fields returns the names of the matched fields
+func (r SearchResult) Fields() []string {
+	fields := make([]string, len(r.Matches))
+	for i, match := range r.Matches {
+		fields[i] = match.Field
+	}
+	return fields
+}
+
+// TitleSnippet returns the whole title with its matches highlighted
+func (r SearchResult) TitleSnippet() Snippet {
+	snippet := Snippet{Text: strings.Join(strings.Fields(r.Title), " ")}
+	for _, match := range r.Matches {
+		if match.Field == "title" {
+			snippet.Highlights = match.Positions
+		}
+	}
+	return snippet
+}
+
+// Snippets returns the snippets of every matched field in order
+func (r SearchResult) Snippets() []Snippet {
+	var snippets []Snippet
+	for _, match := range r.Matches {
+		snippets = append(snippets, match.Snippets...)
+	}
+	return snippets
+}
+
+// NewSearchResults finds where query occurs in each article
+func NewSearchResults(articles []Article, query string) []SearchResult {
+	results := make([]SearchResult, len(articles))
+	for i, article := range articles {
+		results[i] = SearchResult{Article: article, Matches: FindMatches(article, query)}
+	}
+	return results
+}
+
+// FindMatches returns where query occurs in the title, description and
+// content of an article, ignoring case, with keyword-in-context snippets
+func FindMatches(article Article, query string) []FieldMatch {
+	needle := []rune(strings.ToLower(strings.TrimSpace(query)))
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var matches []FieldMatch
+	for _, field := range []struct {
+		name string
+		text string
+	}{
+		{"title", article.Title},
+		{"description", article.Description},
+		{"content", cleanHTML(article.Content)},
+	} {
+		text := []rune(strings.Join(strings.Fields(field.text), " "))
+		positions := findSpans(text, needle)
+		if len(positions) == 0 {
+			continue
+		}
+
+		match := FieldMatch{Field: field.name, Positions: positions}
+		if field.name != "title" {
+			match.Snippets = snippets(text, positions)
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// findSpans returns the non-overlapping occurrences of needle in text,
+// comparing lower-cased runes
+func findSpans(text, needle []rune) []Span {
+	var spans []Span
+	for i := 0; i+len(needle) <= len(text); {
+		if runesEqualFold(text[i:i+len(needle)], needle) {
+			spans = append(spans, Span{Start: i, End: i + len(needle)})
+			i += len(needle)
+			continue
+		}
+		i++
+	}
+	return spans
+}
+
+// runesEqualFold compares a with the lower-cased b, ignoring case
+func runesEqualFold(a, b []rune) bool {
+	for i := range a {
+		if unicode.ToLower(a[i]) != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// snippets cuts excerpts around matches, merging matches close enough to
+// share one
+func snippets(text []rune, positions []Span) []Snippet {
+	var result []Snippet
+	for i := 0; i < len(positions) && len(result) < maxSnippets; {
+		start := wordStart(text, positions[i].Start-snippetContext)
+		end := positions[i].End + snippetContext
+
+		// Take in the following matches that fall inside this excerpt
+		j := i + 1
+		for j < len(positions) && positions[j].Start < end {
+			end = positions[j].End + snippetContext
+			j++
+		}
+		end = wordEnd(text, end)
+
+		snippet := Snippet{Text: string(text[start:end])}
+		offset := start
+		if start > 0 {
+			snippet.Text = "…" + snippet.Text
+			offset--
+		}
+		if end < len(text) {
+			snippet.Text += "…"
+		}
+		for _, position := range positions[i:j] {
+			snippet.Highlights = append(snippet.Highlights, Span{Start: position.Start - offset, End: position.End - offset})
+		}
+
+		result = append(result, snippet)
+		i = j
+	}
+	return result
+}
+
+// wordStart moves i forward to the start of a word, or to 0
+func wordStart(text []rune, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	for j := i; j < len(text) && j < i+snippetContext/3; j++ {
+		if unicode.IsSpace(text[j-1]) && !unicode.IsSpace(text[j]) {
+			return j
+		}
+	}
+	return i
+}
+
+// wordEnd moves i back to the end of a word, or to the end of text
+func wordEnd(text []rune, i int) int {
+	if i >= len(text) {
+		return len(text)
+	}
+	for j := i; j > 0 && j > i-snippetContext/3; j-- {
+		if unicode.IsSpace(text[j]) && !unicode.IsSpace(text[j-1]) {
+			return j
+		}
+	}
+	return i
+}
+
+// Segment is a piece of a snippet, either matched or not
+type Segment struct {
+	Text  string
+	Match bool
+}
+
+// Segments splits the text of a snippet at its highlights
+func (s Snippet) Segments() []Segment {
+	runes := []rune(s.Text)
+	var segments []Segment
+	last := 0
+	for _, span := range s.Highlights {
+		if span.Start > last {
+			segments = append(segments, Segment{Text: string(runes[last:span.Start])})
+		}
+		segments = append(segments, Segment{Text: string(runes[span.Start:span.End]), Match: true})
+		last = span.End
+	}
+	if last < len(runes) {
+		segments = append(segments, Segment{Text: string(runes[last:])})
+	}
+	return segments
+}
+
+// Highlight returns the text of a snippet with every highlight wrapped in
+// open and close
+func (s Snippet) Highlight(open, close string) string {
+	var b strings.Builder
+	for _, segment := range s.Segments() {
+		if segment.Match {
+			b.WriteString(open + segment.Text + close)
+		} else {
+			b.WriteString(segment.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,169 @@
+package news
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		article Article
+		query   string
+		want    []FieldMatch
+	}{
+		{
+			name:    "empty query",
+			article: Article{Title: "Storm in Rotterdam"},
+			query:   "  ",
+		},
+		{
+			name:    "no match",
+			article: Article{Title: "Storm in Rotterdam", Description: "Veel wind"},
+			query:   "regen",
+		},
+		{
+			name:    "title has no snippets",
+			article: Article{Title: "Storm in Rotterdam"},
+			query:   "storm",
+			want:    []FieldMatch{{Field: "title", Positions: []Span{{0, 5}}}},
+		},
+		{
+			name:    "ignores case",
+			article: Article{Title: "Kabinet praat met kabinet"},
+			query:   "KABINET",
+			want:    []FieldMatch{{Field: "title", Positions: []Span{{0, 7}, {18, 25}}}},
+		},
+		{
+			name:    "counts runes",
+			article: Article{Title: "Café in Zürich"},
+			query:   "zürich",
+			want:    []FieldMatch{{Field: "title", Positions: []Span{{8, 14}}}},
+		},
+		{
+			name:    "collapses whitespace",
+			article: Article{Description: "Het  weer\n\nwordt warm"},
+			query:   "weer",
+			want: []FieldMatch{{
+				Field:     "description",
+				Positions: []Span{{4, 8}},
+				Snippets:  []Snippet{{Text: "Het weer wordt warm", Highlights: []Span{{4, 8}}}},
+			}},
+		},
+		{
+			name:    "fields in order",
+			article: Article{Title: "Trein", Description: "Geen trein", Content: "<p>De trein rijdt</p>"},
+			query:   "trein",
+			want: []FieldMatch{
+				{Field: "title", Positions: []Span{{0, 5}}},
+				{Field: "description", Positions: []Span{{5, 10}}, Snippets: []Snippet{{Text: "Geen trein", Highlights: []Span{{5, 10}}}}},
+				{Field: "content", Positions: []Span{{3, 8}}, Snippets: []Snippet{{Text: "De trein rijdt", Highlights: []Span{{3, 8}}}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindMatches(tt.article, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindMatches = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchSnippets(t *testing.T) {
+	words := func(n int) string {
+		return strings.TrimSpace(strings.Repeat("woord ", n))
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		want        []string
+	}{
+		{
+			name:        "short text is shown whole",
+			description: "Het doel is bereikt",
+			want:        []string{"Het [doel] is bereikt"},
+		},
+		{
+			name:        "context is cut at word boundaries",
+			description: words(20) + " doel " + words(20),
+			want:        []string{"…" + words(10) + " [doel] " + words(10) + "…"},
+		},
+		{
+			name:        "match at the start",
+			description: "doel " + words(20),
+			want:        []string{"[doel] " + words(10) + "…"},
+		},
+		{
+			name:        "nearby matches share a snippet",
+			description: words(20) + " doel woord doel " + words(20),
+			want:        []string{"…" + words(10) + " [doel] woord [doel] " + words(10) + "…"},
+		},
+		{
+			name:        "at most two snippets",
+			description: "doel " + words(30) + " doel " + words(30) + " doel " + words(30),
+			want: []string{
+				"[doel] " + words(10) + "…",
+				"…" + words(10) + " [doel] " + words(10) + "…",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := FindMatches(Article{Description: tt.description}, "doel")
+			if len(matches) != 1 {
+				t.Fatalf("FindMatches = %+v, want one description match", matches)
+			}
+			var got []string
+			for _, snippet := range matches[0].Snippets {
+				got = append(got, snippet.Highlight("[", "]"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snippets =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippetSegments(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet Snippet
+		want    []Segment
+	}{
+		{
+			name:    "no highlights",
+			snippet: Snippet{Text: "rustig weer"},
+			want:    []Segment{{Text: "rustig weer"}},
+		},
+		{
+			name:    "highlight in the middle",
+			snippet: Snippet{Text: "a storm b", Highlights: []Span{{2, 7}}},
+			want:    []Segment{{Text: "a "}, {Text: "storm", Match: true}, {Text: " b"}},
+		},
+		{
+			name:    "highlights at both ends",
+			snippet: Snippet{Text: "storm en storm", Highlights: []Span{{0, 5}, {9, 14}}},
+			want:    []Segment{{Text: "storm", Match: true}, {Text: " en "}, {Text: "storm", Match: true}},
+		},
+		{
+			name:    "offsets are runes",
+			snippet: Snippet{Text: "…in Zürich…", Highlights: []Span{{4, 10}}},
+			want:    []Segment{{Text: "…in "}, {Text: "Zürich", Match: true}, {Text: "…"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.snippet.Segments(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Segments = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	snippet := Snippet{Text: "a storm b", Highlights: []Span{{2, 7}}}
+	if got := snippet.Highlight("<mark>", "</mark>"); got != "a <mark>storm</mark> b" {
+		t.Errorf("Highlight = %q", got)
+	}
+}
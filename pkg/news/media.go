@@ -0,0 +1,285 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Media kinds
+const (
+	MediaAudio = "audio"
+	MediaVideo = "video"
+)
+
+// Media represents a playable audio or video enclosure
+type Media struct {
+	URL      string `json:"url"`
+	Type     string `json:"type"`
+	MIMEType string `json:"mime_type,omitempty"`
+	// Duration is the playing time in seconds, when the feed provides it
+	Duration int   `json:"duration,omitempty"`
+	Size     int64 `json:"size,omitempty"`
+}
+
+// DurationString formats the duration as H:MM:SS or M:SS
+func (m Media) DurationString() string {
+	if m.Duration <= 0 {
+		return ""
+	}
+	hours := m.Duration / 3600
+	minutes := (m.Duration % 3600) / 60
+	seconds := m.Duration % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+// Filename returns a file name for saving the media locally
+func (m Media) Filename() string {
+	name := path.Base(strings.SplitN(m.URL, "?", 2)[0])
+	if name == "." || name == "/" || name == "" {
+		name = "media"
+	}
+	return name
+}
+
+// HasMedia reports whether the article carries media of the given type.
+// An empty type matches any media.
+func (a Article) HasMedia(mediaType string) bool {
+	for _, media := range a.Media {
+		if mediaType == "" || media.Type == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByMedia returns the articles that carry media of the given type
+func FilterByMedia(articles []Article, mediaType string) []Article {
+	var filtered []Article
+	for _, article := range articles {
+		if article.HasMedia(mediaType) {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered
+}
+
+// extractMedia collects audio and video from enclosures and media:content
+func extractMedia(item *gofeed.Item) []Media {
+	var media []Media
+	seen := make(map[string]bool)
+
+	duration := 0
+	if item.ITunesExt != nil {
+		duration = parseDuration(item.ITunesExt.Duration)
+	}
+
+	add := func(m Media) {
+		if m.URL == "" || m.Type == "" || seen[m.URL] {
+			return
+		}
+		if m.Duration == 0 {
+			m.Duration = duration
+		}
+		seen[m.URL] = true
+		media = append(media, m)
+	}
+
+	for _, enc := range item.Enclosures {
+		if enc == nil {
+			continue
+		}
+		size, _ := strconv.ParseInt(strings.TrimSpace(enc.Length), 10, 64)
+		add(Media{
+			URL:      enc.URL,
+			Type:     mediaType(enc.Type, ""),
+			MIMEType: enc.Type,
+			Duration: parseDuration(item.Custom[customDuration+enc.URL]),
+			Size:     size,
+		})
+	}
+
+	if item.Extensions != nil {
+		if ext, ok := item.Extensions["media"]; ok {
+			var contents []map[string]string
+			for _, content := range ext["content"] {
+				contents = append(contents, content.Attrs)
+			}
+			for _, group := range ext["group"] {
+				for _, content := range group.Children["content"] {
+					contents = append(contents, content.Attrs)
+				}
+			}
+			for _, attrs := range contents {
+				size, _ := strconv.ParseInt(attrs["fileSize"], 10, 64)
+				add(Media{
+					URL:      attrs["url"],
+					Type:     mediaType(attrs["type"], attrs["medium"]),
+					MIMEType: attrs["type"],
+					Duration: parseDuration(attrs["duration"]),
+					Size:     size,
+				})
+			}
+		}
+	}
+
+	return media
+}
+
+// mediaType maps a MIME type or media:content medium to audio or video
+func mediaType(mimeType, medium string) string {
+	switch {
+	case medium == MediaAudio || strings.HasPrefix(mimeType, "audio/"):
+		return MediaAudio
+	case medium == MediaVideo || strings.HasPrefix(mimeType, "video/"),
+		mimeType == "application/x-mpegURL", mimeType == "application/vnd.apple.mpegurl":
+		return MediaVideo
+	default:
+		return ""
+	}
+}
+
+// parseDuration parses durations given as seconds, MM:SS or HH:MM:SS
+func parseDuration(value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	total := 0
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0
+		}
+		total = total*60 + int(n)
+	}
+	return total
+}
+
+// DownloadMedia saves media to dest, resuming from a partial dest+".part"
+// file when one exists. progress is called with the bytes written so far
+// and the total size (or -1 when unknown).
+func (rf *RSSFetcher) DownloadMedia(ctx context.Context, sourceName string, media Media, dest string, progress func(done, total int64)) error {
+	partFile := dest + ".part"
+
+	var offset int64
+	if info, err := os.Stat(partFile); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := rf.NewRequest(ctx, sourceName, media.URL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", media.URL, err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	// Media files can be large: rely on the context instead of the client timeout
+	client := *rf.client
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", media.URL, err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// Server ignored the range request, start over
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is already complete
+		return os.Rename(partFile, dest)
+	default:
+		return fmt.Errorf("failed to download %s: HTTP %d", media.URL, resp.StatusCode)
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+
+	file, err := os.OpenFile(partFile, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", partFile, err)
+	}
+
+	writer := &progressWriter{done: offset, total: total, progress: progress}
+	if progress != nil {
+		progress(offset, total)
+	}
+
+	_, err = io.Copy(io.MultiWriter(file, writer), resp.Body)
+	closeErr := file.Close()
+	if err != nil {
+		return fmt.Errorf("download interrupted (run again to resume): %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to write %s: %w", partFile, closeErr)
+	}
+
+	return os.Rename(partFile, dest)
+}
+
+// progressWriter reports download progress as bytes are written
+type progressWriter struct {
+	done     int64
+	total    int64
+	progress func(done, total int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.done += int64(len(p))
+	if pw.progress != nil {
+		pw.progress(pw.done, pw.total)
+	}
+	return len(p), nil
+}
+
+// maxImageSize limits the size of downloaded article images
+const maxImageSize = 10 << 20
+
+// FetchImage downloads an article image into memory
+func (rf *RSSFetcher) FetchImage(ctx context.Context, sourceName, url string) ([]byte, error) {
+	req, err := rf.NewRequest(ctx, sourceName, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	resp, err := rf.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image %s is larger than %d MB", url, maxImageSize>>20)
+	}
+
+	return data, nil
+}
@@ -1,22 +1,133 @@
 package news
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Article represents a news article
 type Article struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Content     string    `json:"content"`
-	Link        string    `json:"link"`
-	Published   time.Time `json:"published"`
-	Source      string    `json:"source"`
-	ImageURL    string    `json:"image_url,omitempty"`
-	Categories  []string  `json:"categories,omitempty"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Summary     string      `json:"summary,omitempty"`
+	Content     string      `json:"content"`
+	Link        string      `json:"link"`
+	GUID        string      `json:"guid,omitempty"`
+	Published   time.Time   `json:"published"`
+	Updated     time.Time   `json:"updated,omitzero"`
+	Source      string      `json:"source"`
+	Authors     []Author    `json:"authors,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
+	Categories  []string    `json:"categories,omitempty"`
+	Enclosures  []Enclosure `json:"enclosures,omitempty"`
+	Media       []Media     `json:"media,omitempty"`
+	CommentsURL string      `json:"comments_url,omitempty"`
+	Language    string      `json:"language,omitempty"`
+	Copyright   string      `json:"copyright,omitempty"`
+	// FullContent is set once the article text was extracted from its page
+	FullContent bool `json:"full_content,omitempty"`
+}
+
+// Author represents an article author
+type Author struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Enclosure represents a file attached to an article (audio, video, image)
+type Enclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type,omitempty"`
+	Length int64  `json:"length,omitempty"`
+}
+
+// Key returns the identity used to deduplicate articles: the feed GUID
+// when present, otherwise the article link
+func (a Article) Key() string {
+	if a.GUID != "" {
+		return a.GUID
+	}
+	return a.Link
+}
+
+// HasCategory reports whether the article is tagged with category
+func (a Article) HasCategory(category string) bool {
+	for _, cat := range a.Categories {
+		if strings.EqualFold(cat, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the query occurs in the title, description or
+// content, ignoring case
+func (a Article) Matches(query string) bool {
+	query = strings.ToLower(query)
+	return strings.Contains(strings.ToLower(a.Title), query) ||
+		strings.Contains(strings.ToLower(a.Description), query) ||
+		strings.Contains(strings.ToLower(a.Content), query)
+}
+
+// ID returns a short stable identifier for referring to the article on
+// the command line
+func (a Article) ID() string {
+	sum := sha1.Sum([]byte(a.Key()))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// Slug returns a file-name friendly version of the title, falling back to
+// the ID for titles without letters or digits
+func (a Article) Slug() string {
+	var b strings.Builder
+	dash := false
+	count := 0
+	for _, r := range strings.ToLower(a.Title) {
+		if count >= 60 {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+				count++
+			}
+			b.WriteRune(r)
+			count++
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	if b.Len() == 0 {
+		return a.ID()
+	}
+	return b.String()
+}
+
+// AuthorNames returns the author names joined for display
+func (a Article) AuthorNames() string {
+	return strings.Join(a.AuthorList(), ", ")
+}
+
+// AuthorList lists the names, or else the email addresses, of the authors
+func (a Article) AuthorList() []string {
+	var names []string
+	for _, author := range a.Authors {
+		if author.Name != "" {
+			names = append(names, author.Name)
+		} else if author.Email != "" {
+			names = append(names, author.Email)
+		}
+	}
+	return names
 }
 
 // Source represents a news source
@@ -35,6 +146,27 @@ type NewsService struct {
 	cache       *ArticleCache
 	country     string
 	fullContent bool
+	offline     bool
+	summarize   func(Article) string
+	freshness   Freshness
+}
+
+// ServiceOptions configures a NewsService
+type ServiceOptions struct {
+	Country     string
+	FullContent bool
+	// Offline serves everything from the cache and never touches the network
+	Offline bool
+	// Freshness decides which sources are served from the cache
+	Freshness Freshness
+	Fetcher   FetcherOptions
+	// Summarize, when set, fills in the Summary of the articles the
+	// service returns
+	Summarize func(Article) string
+	// Cache shares the article cache of another service, so services
+	// open at the same time do not overwrite each other's articles; nil
+	// loads the cache from disk
+	Cache *ArticleCache
 }
 
 // NewNewsService creates a new news service
@@ -59,14 +191,65 @@ func NewNewsServiceWithOptions(country string, fullContent bool) *NewsService {
 	}
 }
 
-// GetLatestNews fetches latest news from all sources
+// NewNewsServiceFromOptions creates a news service with a configured fetcher
+func NewNewsServiceFromOptions(opts ServiceOptions) (*NewsService, error) {
+	opts.Fetcher.Offline = opts.Fetcher.Offline || opts.Offline
+
+	fetcher, err := NewRSSFetcherWithOptions(opts.Fetcher)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := opts.Cache
+	if cache == nil {
+		cache = NewArticleCache()
+	}
+
+	return &NewsService{
+		sources:     getSourcesByCountry(opts.Country),
+		fetcher:     fetcher,
+		cache:       cache,
+		country:     opts.Country,
+		fullContent: opts.FullContent,
+		offline:     opts.Fetcher.Offline,
+		freshness:   opts.Freshness,
+		summarize:   opts.Summarize,
+	}, nil
+}
+
+// GetLatestNews returns the latest news from all sources. Sources fetched
+// within their TTL are served from the cache, stale ones are served from
+// the cache and refetched in the background, and the rest are fetched.
 func (ns *NewsService) GetLatestNews(limit int) ([]Article, error) {
+	if ns.offline {
+		articles, err := ns.getCachedNews(limit)
+		ns.Summarize(articles)
+		return articles, err
+	}
+
+	states := LoadFeedStates()
+	cached := ns.cachedBySource(states)
+
 	var allArticles []Article
 
 	for _, source := range ns.sources {
-		articles, err := ns.fetcher.FetchFromSource(source, ns.fullContent)
+		state := ns.freshness.State(source.Name, states[source.Name])
+		if ns.lacksFullContent(cached[source.Name]) {
+			state = SourceExpired
+		}
+		if state != SourceExpired && len(cached[source.Name]) > 0 {
+			allArticles = append(allArticles, cached[source.Name]...)
+			if state == SourceStale {
+				ns.revalidate(source)
+			}
+			continue
+		}
+
+		articles, err := ns.fetchSource(source)
 		if err != nil {
-			fmt.Printf("Warning: Failed to fetch from %s: %v\n", source.Name, err)
+			fmt.Fprintf(os.Stderr, "Warning: Failed to fetch from %s: %v\n", source.Name, err)
+			// Fall back to whatever the cache still has
+			allArticles = append(allArticles, cached[source.Name]...)
 			continue
 		}
 		allArticles = append(allArticles, articles...)
@@ -82,17 +265,149 @@ func (ns *NewsService) GetLatestNews(limit int) ([]Article, error) {
 		allArticles = allArticles[:limit]
 	}
 
-	// Cache articles
-	ns.cache.StoreArticles(allArticles)
-
+	ns.Summarize(allArticles)
 	return allArticles, nil
 }
 
+// FetchSource fetches a single source and stores its articles in the cache
+func (ns *NewsService) FetchSource(source Source) ([]Article, error) {
+	articles, err := ns.fetchSource(source)
+	ns.Summarize(articles)
+	return articles, err
+}
+
+// fetchSource fetches a single source and stores its articles in the
+// cache, without summaries
+func (ns *NewsService) fetchSource(source Source) ([]Article, error) {
+	articles, ttl, err := ns.fetcher.fetchFeed(source, ns.fullContent)
+	recordFetch(source.Name, len(articles), ttl, err)
+	if err != nil {
+		return nil, err
+	}
+
+	ns.cache.StoreArticles(articles)
+	return articles, nil
+}
+
+// Summarize fills in the Summary of articles when the service was created
+// with a summarizer. Articles whose text is too short to summarize keep
+// the summary they have.
+func (ns *NewsService) Summarize(articles []Article) {
+	if ns.summarize == nil {
+		return
+	}
+	for i := range articles {
+		if summary := ns.summarize(articles[i]); summary != "" {
+			articles[i].Summary = summary
+		}
+	}
+}
+
+// IsFresh reports whether a source was fetched within its TTL and can be
+// served from the cache
+func (ns *NewsService) IsFresh(source Source) bool {
+	states := LoadFeedStates()
+	cached := ns.cachedBySource(states)[source.Name]
+	return ns.freshness.State(source.Name, states[source.Name]) == SourceFresh && len(cached) > 0 && !ns.lacksFullContent(cached)
+}
+
+// lacksFullContent reports whether full content was asked for and some of
+// the cached articles only have their feed text, so the cache cannot serve
+// the source
+func (ns *NewsService) lacksFullContent(articles []Article) bool {
+	if !ns.fullContent {
+		return false
+	}
+	for _, article := range articles {
+		if !article.FullContent {
+			return true
+		}
+	}
+	return false
+}
+
+// revalidate refetches a source in the background. WaitForRevalidation
+// waits for it to finish, for up to the fetch timeout.
+func (ns *NewsService) revalidate(source Source) {
+	startRevalidation(ns.fetcher.options.Timeout)
+	go func() {
+		defer revalidations.Done()
+		ns.fetchSource(source)
+	}()
+}
+
+// cachedBySource groups the cached articles by source name, keeping per
+// source as many of the newest articles as its last fetch returned, so a
+// source served from the cache looks like its feed rather than everything
+// ever cached from it
+func (ns *NewsService) cachedBySource(states map[string]FeedState) map[string][]Article {
+	bySource := make(map[string][]Article)
+	for _, article := range ns.cache.GetCachedArticles(0) {
+		bySource[article.Source] = append(bySource[article.Source], article)
+	}
+
+	for source, articles := range bySource {
+		sort.Slice(articles, func(i, j int) bool {
+			return articles[i].Published.After(articles[j].Published)
+		})
+		if n := states[source].LastItems; n > 0 && len(articles) > n {
+			bySource[source] = articles[:n]
+		}
+	}
+	return bySource
+}
+
+// CachedNews returns cached articles from this service's sources, newest first
+func (ns *NewsService) CachedNews(limit int) []Article {
+	sourceNames := make(map[string]bool)
+	for _, source := range ns.sources {
+		sourceNames[source.Name] = true
+	}
+
+	var articles []Article
+	for _, article := range ns.cache.GetCachedArticles(0) {
+		if sourceNames[article.Source] {
+			articles = append(articles, article)
+		}
+	}
+
+	sort.Slice(articles, func(i, j int) bool {
+		return articles[i].Published.After(articles[j].Published)
+	})
+
+	if limit > 0 && len(articles) > limit {
+		articles = articles[:limit]
+	}
+
+	return articles
+}
+
+// Cache returns the article cache of the service
+func (ns *NewsService) Cache() *ArticleCache {
+	return ns.cache
+}
+
+// IsOffline reports whether the service never touches the network
+func (ns *NewsService) IsOffline() bool {
+	return ns.offline
+}
+
+// getCachedNews serves GetLatestNews from the cache in offline mode
+func (ns *NewsService) getCachedNews(limit int) ([]Article, error) {
+	articles := ns.CachedNews(limit)
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("no cached articles for %s: %w", ns.country, ErrOffline)
+	}
+
+	return articles, nil
+}
+
 // SearchArticles searches articles by keywords
 func (ns *NewsService) SearchArticles(query string, limit int) ([]Article, error) {
 	// First try from cache
 	cached := ns.cache.SearchArticles(query, limit)
 	if len(cached) > 0 {
+		ns.Summarize(cached)
 		return cached, nil
 	}
 
@@ -103,12 +418,9 @@ func (ns *NewsService) SearchArticles(query string, limit int) ([]Article, error
 	}
 
 	var matches []Article
-	query = strings.ToLower(query)
 
 	for _, article := range articles {
-		if strings.Contains(strings.ToLower(article.Title), query) ||
-			strings.Contains(strings.ToLower(article.Description), query) ||
-			strings.Contains(strings.ToLower(article.Content), query) {
+		if article.Matches(query) {
 			matches = append(matches, article)
 		}
 	}
@@ -142,17 +454,8 @@ func (ns *NewsService) FilterArticles(sourceName, category string, since time.Ti
 		}
 
 		// Filter by category
-		if category != "" {
-			found := false
-			for _, cat := range article.Categories {
-				if strings.EqualFold(cat, category) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+		if category != "" && !article.HasCategory(category) {
+			continue
 		}
 
 		filtered = append(filtered, article)
@@ -163,9 +466,54 @@ func (ns *NewsService) FilterArticles(sourceName, category string, since time.Ti
 		filtered = filtered[:limit]
 	}
 
+	ns.Summarize(filtered)
 	return filtered, nil
 }
 
+// FindArticle looks up a cached article by its ID or an ID prefix
+func (ns *NewsService) FindArticle(id string) (Article, error) {
+	return ns.cache.FindByID(id)
+}
+
+// DownloadMedia saves an article's media to dest with resume support
+func (ns *NewsService) DownloadMedia(ctx context.Context, article Article, media Media, dest string, progress func(done, total int64)) error {
+	return ns.fetcher.DownloadMedia(ctx, article.Source, media, dest, progress)
+}
+
+// FetchFullContent downloads the article's page, extracts its text and
+// stores the result in the cache. The feed content is kept when the page
+// yields less text than the feed already had. The article is returned with
+// a summary of the new text when the service summarizes.
+func (ns *NewsService) FetchFullContent(ctx context.Context, article Article) (Article, error) {
+	if ns.offline {
+		return article, ErrOffline
+	}
+
+	content, err := ns.fetcher.FetchArticleContent(ctx, article)
+	if err != nil {
+		return article, err
+	}
+
+	if len(content) > len(cleanHTML(article.Content)) {
+		article.Content = content
+	}
+	article.FullContent = true
+	ns.cache.UpdateArticle(article)
+
+	// Summarize the full text rather than keeping the teaser's summary
+	updated := []Article{article}
+	ns.Summarize(updated)
+	return updated[0], nil
+}
+
+// FetchImage downloads the article's image
+func (ns *NewsService) FetchImage(ctx context.Context, article Article) ([]byte, error) {
+	if article.ImageURL == "" {
+		return nil, fmt.Errorf("article has no image")
+	}
+	return ns.fetcher.FetchImage(ctx, article.Source, article.ImageURL)
+}
+
 // GetSources returns available news sources
 func (ns *NewsService) GetSources() []Source {
 	return ns.sources
@@ -190,11 +538,50 @@ func getSourcesByCountry(country string) []Source {
 	}
 }
 
+// CountryForSource returns the country code whose sources include the
+// named source, or an empty string
+func CountryForSource(name string) string {
+	for _, country := range GetAvailableCountries() {
+		for _, source := range getSourcesByCountry(country) {
+			if strings.EqualFold(source.Name, name) {
+				return country
+			}
+		}
+	}
+	return ""
+}
+
 // GetAvailableCountries returns list of supported countries
 func GetAvailableCountries() []string {
 	return []string{"nl", "us", "uk", "de", "fr"}
 }
 
+// Country describes a supported country
+type Country struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Flag     string `json:"flag"`
+}
+
+// countries describes the supported countries by code
+var countries = map[string]Country{
+	"nl": {Code: "nl", Name: "Netherlands", Language: "Dutch", Flag: "🇳🇱"},
+	"us": {Code: "us", Name: "United States", Language: "English", Flag: "🇺🇸"},
+	"uk": {Code: "uk", Name: "United Kingdom", Language: "English", Flag: "🇬🇧"},
+	"de": {Code: "de", Name: "Germany", Language: "German", Flag: "🇩🇪"},
+	"fr": {Code: "fr", Name: "France", Language: "French", Flag: "🇫🇷"},
+}
+
+// GetCountries returns the supported countries in display order
+func GetCountries() []Country {
+	var result []Country
+	for _, code := range GetAvailableCountries() {
+		result = append(result, countries[code])
+	}
+	return result
+}
+
 // getDutchSources returns Dutch news sources
 func getDutchSources() []Source {
 	return []Source{
@@ -233,6 +620,13 @@ func getDutchSources() []Source {
 			Language:    "nl",
 			Category:    "general",
 		},
+		{
+			Name:        "NOS Journaal",
+			URL:         "https://feeds.nos.nl/journaal",
+			Description: "NOS Journaal - Video Bulletins",
+			Language:    "nl",
+			Category:    "video",
+		},
 		{
 			Name:        "NOS Sport",
 			URL:         "https://feeds.nos.nl/nossport",
@@ -281,6 +675,13 @@ func getUSSources() []Source {
 			Language:    "en",
 			Category:    "general",
 		},
+		{
+			Name:        "NPR News Now",
+			URL:         "https://feeds.npr.org/500005/podcast.xml",
+			Description: "NPR - Hourly News Podcast",
+			Language:    "en",
+			Category:    "podcast",
+		},
 	}
 }
 
@@ -355,6 +756,13 @@ func getFrenchSources() []Source {
 			Language:    "fr",
 			Category:    "general",
 		},
+		{
+			Name:        "France 24 Vidéos",
+			URL:         "https://www.france24.com/fr/video/rss",
+			Description: "France 24 - Vidéos",
+			Language:    "fr",
+			Category:    "video",
+		},
 		{
 			Name:        "Liberation",
 			URL:         "https://www.liberation.fr/arc/outboundfeeds/rss/",
@@ -0,0 +1,145 @@
+package news
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// Custom keys used to carry fields that gofeed's universal Item drops
+const (
+	customComments = "nwcli:comments"
+	customRights   = "nwcli:rights"
+	customLanguage = "nwcli:language"
+	// customDuration is suffixed with the enclosure URL
+	customDuration = "nwcli:duration:"
+)
+
+// rssTranslator extends the default RSS translation with item comments
+// and the feed's <ttl>
+type rssTranslator struct {
+	gofeed.DefaultRSSTranslator
+}
+
+// Translate converts an rss.Feed into a gofeed.Feed
+func (t *rssTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	result, err := t.DefaultRSSTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	rssFeed, ok := feed.(*rss.Feed)
+	if !ok {
+		return result, nil
+	}
+
+	if rssFeed.TTL != "" {
+		if result.Custom == nil {
+			result.Custom = make(map[string]string)
+		}
+		result.Custom[customTTL] = strings.TrimSpace(rssFeed.TTL)
+	}
+
+	for i, item := range rssFeed.Items {
+		if i >= len(result.Items) {
+			break
+		}
+		if item.Comments != "" {
+			setCustom(result.Items[i], customComments, item.Comments)
+		}
+	}
+
+	return result, nil
+}
+
+// atomTranslator extends the default Atom translation with reply links
+// and per-entry rights
+type atomTranslator struct {
+	gofeed.DefaultAtomTranslator
+}
+
+// Translate converts an atom.Feed into a gofeed.Feed
+func (t *atomTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	result, err := t.DefaultAtomTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	atomFeed, ok := feed.(*atom.Feed)
+	if !ok {
+		return result, nil
+	}
+
+	for i, entry := range atomFeed.Entries {
+		if i >= len(result.Items) {
+			break
+		}
+		for _, link := range entry.Links {
+			if link.Rel == "replies" && link.Href != "" {
+				setCustom(result.Items[i], customComments, link.Href)
+				break
+			}
+		}
+		if entry.Rights != "" {
+			setCustom(result.Items[i], customRights, entry.Rights)
+		}
+	}
+
+	return result, nil
+}
+
+// jsonTranslator extends the default JSON Feed translation with
+// attachment sizes and per-item language
+type jsonTranslator struct {
+	gofeed.DefaultJSONTranslator
+}
+
+// Translate converts a json.Feed into a gofeed.Feed
+func (t *jsonTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	result, err := t.DefaultJSONTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonFeed, ok := feed.(*json.Feed)
+	if !ok {
+		return result, nil
+	}
+
+	for i, item := range jsonFeed.Items {
+		if i >= len(result.Items) {
+			break
+		}
+		if item.Language != "" {
+			setCustom(result.Items[i], customLanguage, item.Language)
+		}
+		if item.Attachments == nil {
+			continue
+		}
+		for _, attachment := range *item.Attachments {
+			if attachment.DurationInSeconds > 0 {
+				setCustom(result.Items[i], customDuration+attachment.URL,
+					strconv.FormatInt(attachment.DurationInSeconds, 10))
+			}
+			for _, enc := range result.Items[i].Enclosures {
+				if enc.URL == attachment.URL && enc.Length == "" && attachment.SizeInBytes > 0 {
+					enc.Length = strconv.FormatInt(attachment.SizeInBytes, 10)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// setCustom stores a value in an item's custom field map
+func setCustom(item *gofeed.Item, key, value string) {
+	if item.Custom == nil {
+		item.Custom = make(map[string]string)
+	}
+	item.Custom[key] = value
+}
@@ -0,0 +1,96 @@
+package offline
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+	"strings"
+
+	// Register the decoders of common feed image formats
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// maxImageWidth is the width images are scaled down to
+	maxImageWidth = 960
+	// jpegQuality is the quality of re-encoded images
+	jpegQuality = 80
+)
+
+// processImage scales an image down to maxImageWidth and re-encodes it as
+// JPEG. Formats the standard library cannot decode, such as WebP, are kept
+// as they are. It returns the image data and its file extension.
+func processImage(data []byte) ([]byte, string) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return data, imageExtension(data)
+	}
+
+	if img.Bounds().Dx() > maxImageWidth {
+		img = scaleDown(img, maxImageWidth)
+	}
+
+	var b bytes.Buffer
+	if err := jpeg.Encode(&b, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return data, imageExtension(data)
+	}
+	return b.Bytes(), ".jpg"
+}
+
+// imageExtension guesses the file extension from the image data
+func imageExtension(data []byte) string {
+	switch contentType := http.DetectContentType(data); {
+	case contentType == "image/webp":
+		return ".webp"
+	case strings.HasPrefix(contentType, "image/svg"), bytes.Contains(data[:min(len(data), 512)], []byte("<svg")):
+		return ".svg"
+	case strings.HasPrefix(contentType, "image/"):
+		return "." + strings.TrimPrefix(contentType, "image/")
+	default:
+		return ".img"
+	}
+}
+
+// scaleDown resizes img to the given width, keeping its aspect ratio. Each
+// target pixel averages the source pixels it covers.
+func scaleDown(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := y * src.Rect.Dy() / height
+		y1 := max((y+1)*src.Rect.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * src.Rect.Dx() / width
+			x1 := max((x+1)*src.Rect.Dx()/width, x0+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			p := dst.Pix[y*dst.Stride+x*4 : y*dst.Stride+x*4+4]
+			p[0], p[1], p[2], p[3] = uint8(r/n), uint8(g/n), uint8(b/n), uint8(a/n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,117 @@
+// Package offline loads what reading articles away from the network
+// needs: their full text and their images, scaled down for local copies.
+package offline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"nwcli/pkg/news"
+)
+
+// workers is the number of articles loaded concurrently
+const workers = 4
+
+// Fetcher downloads the full text and images of articles
+type Fetcher interface {
+	FetchFullContent(ctx context.Context, article news.Article) (news.Article, error)
+	FetchImage(ctx context.Context, article news.Article) ([]byte, error)
+}
+
+// Options configures Load
+type Options struct {
+	// Fetcher downloads full text and images; nil keeps articles as they
+	// are in the cache
+	Fetcher Fetcher
+	// Images downloads article images when set
+	Images bool
+	// Progress is called after each article is loaded
+	Progress func(done, total int)
+}
+
+// Item is an article with its image
+type Item struct {
+	Article news.Article
+	// Image is the scaled down image, or nil when there is none
+	Image []byte
+	// ImageExt is the file extension of Image, e.g. ".jpg"
+	ImageExt string
+	// Failure describes what could not be loaded
+	Failure string
+}
+
+// Load fetches the full text and image of each article, several at a
+// time. Items keep the order of articles. Articles that could not be
+// loaded keep their cached content and describe why in Failure.
+func Load(ctx context.Context, articles []news.Article, opts Options) []Item {
+	items := make([]Item, len(articles))
+	for i, article := range articles {
+		items[i].Article = article
+	}
+	if opts.Fetcher == nil {
+		return items
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	done := 0
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				item := loadItem(ctx, opts, articles[i])
+
+				mu.Lock()
+				items[i] = item
+				done++
+				if opts.Progress != nil {
+					opts.Progress(done, len(articles))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range articles {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return items
+}
+
+// loadItem fetches an article's full text and image
+func loadItem(ctx context.Context, opts Options, article news.Article) Item {
+	item := Item{Article: article}
+	var failures []string
+
+	if !article.FullContent {
+		if full, err := opts.Fetcher.FetchFullContent(ctx, article); err == nil {
+			item.Article = full
+		} else {
+			failures = append(failures, "text: "+err.Error())
+		}
+	}
+
+	if opts.Images && article.ImageURL != "" {
+		if data, err := opts.Fetcher.FetchImage(ctx, article); err == nil {
+			item.Image, item.ImageExt = processImage(data)
+		} else {
+			failures = append(failures, "image: "+err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		item.Failure = fmt.Sprintf("%s (%s)", article.Title, strings.Join(failures, "; "))
+	}
+	return item
+}
@@ -0,0 +1,121 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sparkBlocks are the levels of a sparkline, from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of block characters scaled to the
+// largest value. Zero values use the lowest block.
+func Sparkline(values []int) string {
+	top := maxValue(values)
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if top > 0 && v > 0 {
+			level = 1 + v*(len(sparkBlocks)-2)/top
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// Bar draws value as a horizontal bar of up to width cells, scaled to top
+func Bar(value, top, width int) string {
+	if top <= 0 || value <= 0 {
+		return ""
+	}
+	cells := value * width / top
+	if cells == 0 {
+		return "▏"
+	}
+	return strings.Repeat("█", cells)
+}
+
+// formatBytes formats a byte count for display
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// hourAxis labels the hours under a 24-hour sparkline
+const hourAxis = "0     6     12    18   23"
+
+// dayCounts returns the counts of a histogram by day
+func dayCounts(days []DayCount) []int {
+	counts := make([]int, len(days))
+	for i, day := range days {
+		counts[i] = day.Count
+	}
+	return counts
+}
+
+// maxValue returns the largest of values, or 0
+func maxValue(values []int) int {
+	top := 0
+	for _, v := range values {
+		top = max(top, v)
+	}
+	return top
+}
+
+// publicationCharts draws the publication histograms of stats as text: a
+// sparkline by hour and a bar chart by day
+func publicationCharts(stats Stats) string {
+	var b strings.Builder
+
+	b.WriteString("By hour (local time)\n")
+	b.WriteString(Sparkline(stats.ByHour) + "\n")
+	b.WriteString(hourAxis + "\n")
+
+	if len(stats.ByDay) > 0 {
+		b.WriteString("\nBy day\n")
+		top := maxValue(dayCounts(stats.ByDay))
+		width := len(fmt.Sprint(top))
+		for _, day := range stats.ByDay {
+			label := day.Date
+			if t, err := time.Parse("2006-01-02", day.Date); err == nil {
+				label = t.Format("Mon Jan 02")
+			}
+			line := fmt.Sprintf("%s %*d %s", label, width, day.Count, Bar(day.Count, top, 40))
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// healthLine describes the fetch history of a source in one line
+func healthLine(health SourceHealth) string {
+	parts := []string{
+		fmt.Sprintf("%d cached", health.Articles),
+		fmt.Sprintf("%s (TTL %s)", health.State, time.Duration(health.TTLSeconds)*time.Second),
+	}
+	if health.LastSuccess.IsZero() {
+		parts = append(parts, "never fetched successfully")
+	} else {
+		parts = append(parts, "last fetched "+formatTimeAgo(health.LastSuccess))
+	}
+	if health.FailureStreak > 0 {
+		failures := fmt.Sprintf("%d failures in a row", health.FailureStreak)
+		if health.FailureStreak == 1 {
+			failures = "1 failure"
+		}
+		parts = append(parts, failures)
+	}
+	if health.Fetches > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f items per fetch", health.AverageItems))
+	}
+	return strings.Join(parts, ", ")
+}
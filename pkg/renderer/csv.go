@@ -0,0 +1,138 @@
+package renderer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+)
+
+func init() {
+	Register("csv", func() (Renderer, error) {
+		return CSVRenderer{comma: ','}, nil
+	})
+	Register("tsv", func() (Renderer, error) {
+		return CSVRenderer{comma: '\t'}, nil
+	})
+}
+
+// CSVRenderer renders tables with a header row, separated by comma. Fields
+// with several values, such as categories, are joined with "; ".
+type CSVRenderer struct {
+	comma rune
+}
+
+// ArticleColumns lists the article columns
+var ArticleColumns = []string{"id", "title", "source", "authors", "published", "link", "categories", "description", "summary"}
+
+// articleRow returns the article columns
+func articleRow(article news.Article) []string {
+	return []string{
+		article.ID(),
+		article.Title,
+		article.Source,
+		article.AuthorNames(),
+		FormatTime(article.Published),
+		article.Link,
+		strings.Join(article.Categories, "; "),
+		article.Description,
+		article.Summary,
+	}
+}
+
+// RenderArticles renders one row per article
+func (cr CSVRenderer) RenderArticles(articles []news.Article, title string) (string, error) {
+	rows := make([][]string, 0, len(articles)+1)
+	rows = append(rows, ArticleColumns)
+	for _, article := range articles {
+		rows = append(rows, articleRow(article))
+	}
+	return cr.render(rows)
+}
+
+// RenderSingleArticle renders the article columns plus its content
+func (cr CSVRenderer) RenderSingleArticle(article news.Article) (string, error) {
+	header := append(append([]string{}, ArticleColumns...), "content")
+	return cr.render([][]string{header, append(articleRow(article), article.Content)})
+}
+
+// RenderSources renders one row per source
+func (cr CSVRenderer) RenderSources(sources []news.Source) (string, error) {
+	rows := [][]string{{"name", "url", "description", "language", "category"}}
+	for _, source := range sources {
+		rows = append(rows, []string{source.Name, source.URL, source.Description, source.Language, source.Category})
+	}
+	return cr.render(rows)
+}
+
+// RenderCountries renders one row per country
+func (cr CSVRenderer) RenderCountries(countries []news.Country) (string, error) {
+	rows := [][]string{{"code", "name", "language"}}
+	for _, country := range countries {
+		rows = append(rows, []string{country.Code, country.Name, country.Language})
+	}
+	return cr.render(rows)
+}
+
+// RenderStats renders statistics as metric, name and value rows
+func (cr CSVRenderer) RenderStats(stats Stats) (string, error) {
+	rows := [][]string{
+		{"metric", "name", "value"},
+		{"total", "", strconv.Itoa(stats.Total)},
+		{"stale", "", strconv.FormatBool(stats.Stale)},
+		{"disk_size", "", strconv.FormatInt(stats.DiskSize, 10)},
+	}
+	if stats.Oldest != nil {
+		rows = append(rows,
+			[]string{"oldest", stats.Oldest.Title, FormatTime(stats.Oldest.Published)},
+			[]string{"newest", stats.Newest.Title, FormatTime(stats.Newest.Published)},
+		)
+	}
+	for _, source := range stats.Sources {
+		rows = append(rows, []string{"source", source.Name, strconv.Itoa(source.Count)})
+	}
+	for _, country := range stats.Countries {
+		rows = append(rows, []string{"country", country.Name, strconv.Itoa(country.Count)})
+	}
+	for _, category := range stats.Categories {
+		rows = append(rows, []string{"category", category.Name, strconv.Itoa(category.Count)})
+	}
+	for hour, count := range stats.ByHour {
+		rows = append(rows, []string{"hour", strconv.Itoa(hour), strconv.Itoa(count)})
+	}
+	for _, day := range stats.ByDay {
+		rows = append(rows, []string{"day", day.Date, strconv.Itoa(day.Count)})
+	}
+	for _, health := range stats.Health {
+		rows = append(rows,
+			[]string{"state", health.Name, health.State},
+			[]string{"ttl_seconds", health.Name, strconv.Itoa(health.TTLSeconds)},
+			[]string{"last_success", health.Name, FormatTime(health.LastSuccess)},
+			[]string{"failure_streak", health.Name, strconv.Itoa(health.FailureStreak)},
+			[]string{"average_items", health.Name, strconv.FormatFloat(health.AverageItems, 'f', 1, 64)},
+		)
+	}
+	return cr.render(rows)
+}
+
+// render writes rows as CSV
+func (cr CSVRenderer) render(rows [][]string) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = cr.comma
+	if err := w.WriteAll(rows); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return b.String(), nil
+}
+
+// FormatTime formats t as RFC 3339, or an empty string for the zero time
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
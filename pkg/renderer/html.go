@@ -0,0 +1,274 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/textutil"
+
+	"github.com/yuin/goldmark"
+)
+
+func init() {
+	Register("html", func() (Renderer, error) {
+		return HTMLRenderer{}, nil
+	})
+}
+
+// HTMLStyle is the stylesheet of HTML output. It follows the reader's
+// light or dark preference.
+const HTMLStyle = `:root {
+  --bg: #fdfcf9; --fg: #1f2328; --muted: #6a737d; --accent: #c0392b;
+  --card: #ffffff; --border: #e1e4e8; --link: #1f6feb;
+}
+@media (prefers-color-scheme: dark) {
+  :root {
+    --bg: #161b22; --fg: #e6edf3; --muted: #8b949e; --accent: #ff7b72;
+    --card: #0d1117; --border: #30363d; --link: #58a6ff;
+  }
+}
+* { box-sizing: border-box; }
+body {
+  margin: 0 auto; max-width: 72rem; padding: 1.5rem;
+  background: var(--bg); color: var(--fg);
+  font: 18px/1.6 Georgia, "Times New Roman", serif;
+}
+a { color: var(--link); }
+header.masthead { text-align: center; border-bottom: 3px double var(--fg); margin-bottom: 1.5rem; }
+header.masthead h1 { font-size: 2.6rem; margin: 0.2rem 0; letter-spacing: 0.04em; }
+.meta { color: var(--muted); font-style: italic; font-size: 0.9rem; }
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(18rem, 1fr)); gap: 1.5rem; }
+.card { background: var(--card); border: 1px solid var(--border); border-radius: 6px; padding: 1rem; }
+.card h2 { font-size: 1.2rem; margin: 0 0 0.4rem; }
+.card h2 a { color: var(--fg); text-decoration: none; }
+.card img, article img { width: 100%; height: auto; border-radius: 4px; }
+.lead { grid-column: 1 / -1; }
+.lead h2 { font-size: 2rem; }
+.tags span { display: inline-block; border: 1px solid var(--border); border-radius: 1rem; padding: 0 0.6rem; margin: 0 0.3rem 0.3rem 0; font-size: 0.8rem; }
+article { max-width: 42rem; margin: 0 auto; }
+article h1 { font-size: 2.2rem; line-height: 1.2; }
+article blockquote { border-left: 4px solid var(--accent); margin: 1rem 0; padding: 0 1rem; color: var(--muted); }
+table { border-collapse: collapse; }
+td, th { border-bottom: 1px solid var(--border); padding: 0.3rem 0.8rem; text-align: left; }
+input[type=search] { width: 100%; font: inherit; padding: 0.5rem; margin-bottom: 1rem; background: var(--card); color: var(--fg); border: 1px solid var(--border); border-radius: 4px; }
+mark { background: #ffe58f; color: #1f2328; padding: 0 0.1em; }
+footer { margin-top: 2rem; color: var(--muted); font-size: 0.85rem; text-align: center; }
+`
+
+// htmlTemplates are the pages of HTML output
+var htmlTemplates = template.Must(template.New("html").Funcs(HTMLFuncs).Parse(`
+{{define "page"}}<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<header class="masthead"><h1>{{.Title}}</h1><p class="meta">{{.Generated}}</p></header>
+{{template "body" .}}
+<footer>Generated with NWCLI</footer>
+</body>
+</html>
+{{end}}
+
+{{define "articles"}}{{if not .Data}}<p>📭 No articles found</p>{{end}}
+<div class="grid">
+{{range $i, $a := .Data}}<div class="card{{if eq $i 0}} lead{{end}}">
+{{with imageSrc $a}}<img src="{{.}}" alt="" loading="lazy">{{end}}
+<h2><a href="{{$a.Link}}">{{$a.Title}}</a></h2>
+<p class="meta">{{byline $a}}</p>
+<p>{{teaser $a}}</p>
+{{if $a.Categories}}<p class="tags">{{range $a.Categories}}<span>{{.}}</span>{{end}}</p>{{end}}
+</div>
+{{end}}</div>
+{{end}}
+
+{{define "search"}}{{if not .Data}}<p>🔍 No matches</p>{{end}}
+<div class="grid">
+{{range .Data}}<div class="card">
+<h2><a href="{{.Link}}">{{markHTML .TitleSnippet}}</a></h2>
+<p class="meta">{{byline .Article}}{{with .Fields}} • matched in {{join . ", "}}{{end}}</p>
+{{range .Snippets}}<p>{{markHTML .}}</p>
+{{end}}</div>
+{{end}}</div>
+{{end}}
+
+{{define "article"}}<article>
+<h1>{{.Data.Title}}</h1>
+<p class="meta">{{byline .Data}}</p>
+{{with imageSrc .Data}}<img src="{{.}}" alt="">{{end}}
+{{if .Data.Summary}}<blockquote>{{.Data.Summary}}</blockquote>{{end}}
+{{articleHTML .Data}}
+<p><a href="{{.Data.Link}}">Read the original article</a></p>
+</article>
+{{end}}
+
+{{define "sources"}}<table>
+<tr><th>Name</th><th>Category</th><th>Language</th><th>Feed</th></tr>
+{{range .Data}}<tr><td>{{.Name}}<br><span class="meta">{{.Description}}</span></td><td>{{.Category}}</td><td>{{.Language}}</td><td><a href="{{.URL}}">RSS</a></td></tr>
+{{end}}</table>
+{{end}}
+
+{{define "countries"}}<table>
+<tr><th>Country</th><th>Code</th><th>Language</th></tr>
+{{range .Data}}<tr><td>{{.Flag}} {{.Name}}</td><td><code>{{.Code}}</code></td><td>{{.Language}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{define "stats"}}<p><strong>{{.Data.Total}}</strong> articles • {{if .Data.Stale}}stale{{else}}fresh{{end}}{{if .Data.DiskSize}} • {{bytes .Data.DiskSize}}{{end}}</p>
+{{with .Data.Newest}}<p class="meta">Newest: {{.Published.Format "Jan 2, 2006 15:04"}} — {{.Title}} ({{.Source}})</p>{{end}}
+{{with .Data.Oldest}}<p class="meta">Oldest: {{.Published.Format "Jan 2, 2006 15:04"}} — {{.Title}} ({{.Source}})</p>{{end}}
+<h2>Sources</h2>
+<table>{{range .Data.Sources}}<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>{{end}}</table>
+{{if .Data.Countries}}<h2>Countries</h2>
+<table>{{range .Data.Countries}}<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>{{end}}</table>{{end}}
+{{if .Data.Categories}}<h2>Categories</h2>
+<table>{{range .Data.Categories}}<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>{{end}}</table>{{end}}
+{{if .Data.Newest}}<h2>Publication</h2>
+<pre>{{charts .Data}}</pre>{{end}}
+{{if .Data.Health}}<h2>Source Health</h2>
+<table>
+<tr><th>Source</th><th>Cached</th><th>State</th><th>Last success</th><th>Failures</th><th>Items per fetch</th></tr>
+{{range .Data.Health}}<tr><td>{{.Name}}{{if and .FailureStreak .LastError}}<br><span class="meta">{{.LastError}}</span>{{end}}</td><td>{{.Articles}}</td><td>{{.State}}</td><td>{{if .LastSuccess.IsZero}}never{{else}}{{.LastSuccess.Format "Jan 2, 2006 15:04"}}{{end}}</td><td>{{.FailureStreak}}</td><td>{{printf "%.1f" .AverageItems}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}
+`))
+
+// HTMLFuncs are the template functions shared by HTML pages
+var HTMLFuncs = template.FuncMap{
+	"byline":      Byline,
+	"teaser":      Teaser,
+	"articleHTML": ArticleHTML,
+	"imageSrc":    func(article news.Article) any { return article.ImageURL },
+	"bytes":       formatBytes,
+	"charts":      publicationCharts,
+	"markHTML":    markHTML,
+	"join":        strings.Join,
+}
+
+// HTMLRenderer renders standalone HTML documents
+type HTMLRenderer struct {
+	// ImageSources replaces the image URLs of articles, by article key,
+	// e.g. with cid: URLs of images attached to an email
+	ImageSources map[string]template.URL
+}
+
+// htmlPage is the data of a page
+type htmlPage struct {
+	Title     string
+	Lang      string
+	Style     template.CSS
+	Generated string
+	Data      any
+}
+
+// RenderArticles renders article cards, the first one as the lead story
+func (hr HTMLRenderer) RenderArticles(articles []news.Article, title string) (string, error) {
+	return hr.render("articles", title, articles)
+}
+
+// RenderSingleArticle renders an article with its full text
+func (hr HTMLRenderer) RenderSingleArticle(article news.Article) (string, error) {
+	return hr.render("article", article.Title, article)
+}
+
+// RenderSources renders sources as a table
+func (hr HTMLRenderer) RenderSources(sources []news.Source) (string, error) {
+	return hr.render("sources", "Available News Sources", sources)
+}
+
+// RenderCountries renders countries as a table
+func (hr HTMLRenderer) RenderCountries(countries []news.Country) (string, error) {
+	return hr.render("countries", "Supported Countries", countries)
+}
+
+// RenderStats renders statistics as tables
+func (hr HTMLRenderer) RenderStats(stats Stats) (string, error) {
+	return hr.render("stats", "News Statistics", stats)
+}
+
+// render executes the page template with the named body template
+func (hr HTMLRenderer) render(body, title string, data any) (string, error) {
+	tmpl, err := htmlTemplates.Clone()
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmpl.New("body").Parse(`{{template "` + body + `" .}}`); err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{"imageSrc": hr.imageSrc})
+
+	page := htmlPage{
+		Title:     title,
+		Lang:      "en",
+		Style:     template.CSS(HTMLStyle),
+		Generated: time.Now().Format("Monday, January 2, 2006 at 15:04"),
+		Data:      data,
+	}
+	if article, ok := data.(news.Article); ok && article.Language != "" {
+		page.Lang = article.Language
+	}
+
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, "page", page); err != nil {
+		return "", fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return b.String(), nil
+}
+
+// imageSrc returns the image URL of an article
+func (hr HTMLRenderer) imageSrc(article news.Article) any {
+	if src, ok := hr.ImageSources[article.Key()]; ok {
+		return src
+	}
+	return article.ImageURL
+}
+
+// ArticleHTML converts an article's content, or its description when it
+// has none, from markdown to HTML. Raw HTML in the source is dropped.
+func ArticleHTML(article news.Article) template.HTML {
+	text := article.Content
+	if text == "" {
+		text = article.Description
+	}
+	return MarkdownHTML(text)
+}
+
+// MarkdownHTML converts markdown to HTML, dropping raw HTML
+func MarkdownHTML(md string) template.HTML {
+	var b bytes.Buffer
+	if err := goldmark.Convert([]byte(md), &b); err != nil {
+		return template.HTML("<p>" + template.HTMLEscapeString(md) + "</p>")
+	}
+	return template.HTML(b.String())
+}
+
+// Byline describes an article's source, authors and publication time
+func Byline(article news.Article) string {
+	byline := article.Source
+	if authors := article.AuthorNames(); authors != "" {
+		byline += " • by " + authors
+	}
+	if !article.Published.IsZero() {
+		byline += " • " + article.Published.Format("Monday, January 2, 2006 at 15:04")
+	}
+	return byline
+}
+
+// Teaser returns the summary or description shown on article cards
+func Teaser(article news.Article) string {
+	text := article.Summary
+	if text == "" {
+		text = article.Description
+	}
+	if text == "" {
+		text = article.Content
+	}
+	return textutil.Truncate(strings.Join(strings.Fields(text), " "), 300)
+}
@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"nwcli/pkg/news"
+)
+
+func init() {
+	Register("json", func() (Renderer, error) {
+		return JSONRenderer{}, nil
+	})
+	Register("ndjson", func() (Renderer, error) {
+		return JSONRenderer{lines: true}, nil
+	})
+}
+
+// JSONRenderer renders indented JSON, or newline-delimited JSON with one
+// object per line when lines is set
+type JSONRenderer struct {
+	lines bool
+}
+
+// RenderArticles renders articles as an array, or one article per line
+func (jr JSONRenderer) RenderArticles(articles []news.Article, title string) (string, error) {
+	return renderJSONList(jr, articles)
+}
+
+// RenderSingleArticle renders an article as an object
+func (jr JSONRenderer) RenderSingleArticle(article news.Article) (string, error) {
+	return jr.render(article)
+}
+
+// RenderSources renders sources as an array, or one source per line
+func (jr JSONRenderer) RenderSources(sources []news.Source) (string, error) {
+	return renderJSONList(jr, sources)
+}
+
+// RenderCountries renders countries as an array, or one country per line
+func (jr JSONRenderer) RenderCountries(countries []news.Country) (string, error) {
+	return renderJSONList(jr, countries)
+}
+
+// RenderStats renders statistics as an object
+func (jr JSONRenderer) RenderStats(stats Stats) (string, error) {
+	return jr.render(stats)
+}
+
+// render marshals a single value, indented unless in line mode
+func (jr JSONRenderer) render(v any) (string, error) {
+	var data []byte
+	var err error
+	if jr.lines {
+		data, err = json.Marshal(v)
+	} else {
+		data, err = json.MarshalIndent(v, "", "  ")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// renderJSONList marshals items as an array, or one item per line in
+// line mode
+func renderJSONList[T any](jr JSONRenderer, items []T) (string, error) {
+	if !jr.lines {
+		if items == nil {
+			items = []T{}
+		}
+		return jr.render(items)
+	}
+
+	var b strings.Builder
+	for _, item := range items {
+		line, err := jr.render(item)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(line)
+	}
+	return b.String(), nil
+}
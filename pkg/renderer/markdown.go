@@ -6,10 +6,17 @@ import (
 	"time"
 
 	"nwcli/pkg/news"
+	"nwcli/pkg/textutil"
 
 	"github.com/charmbracelet/glamour"
 )
 
+func init() {
+	Register("markdown", func() (Renderer, error) {
+		return NewMarkdownRenderer()
+	})
+}
+
 // MarkdownRenderer handles markdown rendering with Glamour
 type MarkdownRenderer struct {
 	glamour *glamour.TermRenderer
@@ -55,24 +62,34 @@ func (mr *MarkdownRenderer) RenderArticles(articles []news.Article, title string
 		sourceInfo := fmt.Sprintf("**%s** • %s",
 			article.Source,
 			formatTimeAgo(article.Published))
+		if authors := article.AuthorNames(); authors != "" {
+			sourceInfo = fmt.Sprintf("**%s** • by %s • %s",
+				article.Source,
+				authors,
+				formatTimeAgo(article.Published))
+		}
 
 		md.WriteString(fmt.Sprintf("## %s\n\n", article.Title))
 		md.WriteString(fmt.Sprintf("*%s*\n\n", sourceInfo))
 
+		// Audio and video
+		for _, media := range article.Media {
+			md.WriteString(fmt.Sprintf("%s\n\n", mediaLine(media)))
+		}
+
 		// Image if available
 		if article.ImageURL != "" {
 			md.WriteString(fmt.Sprintf("![Article Image](%s)\n\n", article.ImageURL))
 		}
 
-		// Description/Content
-		if article.Description != "" {
+		// Summary/Description/Content
+		if article.Summary != "" {
+			md.WriteString(fmt.Sprintf("%s\n\n", article.Summary))
+		} else if article.Description != "" {
 			md.WriteString(fmt.Sprintf("%s\n\n", article.Description))
 		} else if article.Content != "" {
 			// Truncate content if it's very long
-			content := article.Content
-			if len(content) > 500 {
-				content = content[:497] + "..."
-			}
+			content := textutil.Truncate(article.Content, 500)
 			md.WriteString(fmt.Sprintf("%s\n\n", content))
 		}
 
@@ -102,6 +119,12 @@ func (mr *MarkdownRenderer) RenderArticles(articles []news.Article, title string
 
 // RenderSingleArticle renders a single article in detail
 func (mr *MarkdownRenderer) RenderSingleArticle(article news.Article) (string, error) {
+	return mr.glamour.Render(ArticleMarkdown(article))
+}
+
+// ArticleMarkdown returns the markdown source of a single article, as
+// shown by RenderSingleArticle
+func ArticleMarkdown(article news.Article) string {
 	var md strings.Builder
 
 	// Title
@@ -109,10 +132,21 @@ func (mr *MarkdownRenderer) RenderSingleArticle(article news.Article) (string, e
 
 	// Metadata
 	md.WriteString(fmt.Sprintf("**Source:** %s\n", article.Source))
+	if authors := article.AuthorNames(); authors != "" {
+		md.WriteString(fmt.Sprintf("**By:** %s\n", authors))
+	}
 	md.WriteString(fmt.Sprintf("**Published:** %s (%s)\n",
 		article.Published.Format("Monday, January 2, 2006 at 15:04"),
 		formatTimeAgo(article.Published)))
-	md.WriteString(fmt.Sprintf("**URL:** %s\n\n", article.Link))
+	if !article.Updated.IsZero() {
+		md.WriteString(fmt.Sprintf("**Updated:** %s\n",
+			article.Updated.Format("Monday, January 2, 2006 at 15:04")))
+	}
+	md.WriteString(fmt.Sprintf("**URL:** %s\n", article.Link))
+	if article.CommentsURL != "" {
+		md.WriteString(fmt.Sprintf("**Comments:** %s\n", article.CommentsURL))
+	}
+	md.WriteString("\n")
 
 	// Categories
 	if len(article.Categories) > 0 {
@@ -133,6 +167,11 @@ func (mr *MarkdownRenderer) RenderSingleArticle(article news.Article) (string, e
 		md.WriteString(fmt.Sprintf("![Article Image](%s)\n\n", article.ImageURL))
 	}
 
+	// Summary
+	if article.Summary != "" {
+		md.WriteString(fmt.Sprintf("> %s\n\n", article.Summary))
+	}
+
 	// Content
 	if article.Content != "" {
 		md.WriteString(fmt.Sprintf("%s\n\n", article.Content))
@@ -140,7 +179,20 @@ func (mr *MarkdownRenderer) RenderSingleArticle(article news.Article) (string, e
 		md.WriteString(fmt.Sprintf("%s\n\n", article.Description))
 	}
 
-	return mr.glamour.Render(md.String())
+	// Attachments
+	if len(article.Enclosures) > 0 {
+		md.WriteString("**Attachments:**\n\n")
+		for _, enc := range article.Enclosures {
+			md.WriteString(fmt.Sprintf("- [%s](%s)\n", enclosureLabel(enc), enc.URL))
+		}
+		md.WriteString("\n")
+	}
+
+	if article.Copyright != "" {
+		md.WriteString(fmt.Sprintf("*© %s*\n", strings.TrimPrefix(article.Copyright, "©")))
+	}
+
+	return md.String()
 }
 
 // RenderSources renders available news sources
@@ -180,9 +232,27 @@ func (mr *MarkdownRenderer) RenderMessage(title, message string) (string, error)
 	return mr.glamour.Render(md)
 }
 
+// RenderCountries renders the supported countries
+func (mr *MarkdownRenderer) RenderCountries(countries []news.Country) (string, error) {
+	var md strings.Builder
+
+	md.WriteString("# 🌍 Supported Countries\n\n")
+	md.WriteString("NWCLI supports news sources from the following countries:\n\n")
+
+	for _, country := range countries {
+		md.WriteString(fmt.Sprintf("- **%s %s (%s)** - `%s`\n", country.Flag, country.Name, country.Language, country.Code))
+	}
+
+	md.WriteString("\n---\n\n")
+	md.WriteString("**Usage:** Use the country code with `--country` flag\n\n")
+	md.WriteString("**Example:** `nwcli latest --country us --limit 10`\n")
+
+	return mr.glamour.Render(md.String())
+}
+
 // RenderStats renders news statistics
-func (mr *MarkdownRenderer) RenderStats(articles []news.Article) (string, error) {
-	if len(articles) == 0 {
+func (mr *MarkdownRenderer) RenderStats(stats Stats) (string, error) {
+	if stats.Total == 0 {
 		return mr.RenderMessage("📊 No Statistics", "No articles available for analysis.")
 	}
 
@@ -192,57 +262,93 @@ func (mr *MarkdownRenderer) RenderStats(articles []news.Article) (string, error)
 	md.WriteString("---\n\n")
 
 	// Total articles
-	md.WriteString(fmt.Sprintf("**Total Articles:** %d\n\n", len(articles)))
-
-	// Sources breakdown
-	sourceCount := make(map[string]int)
-	categoryCount := make(map[string]int)
-
-	for _, article := range articles {
-		sourceCount[article.Source]++
-		for _, cat := range article.Categories {
-			categoryCount[cat]++
-		}
+	md.WriteString(fmt.Sprintf("**Total Articles:** %d\n\n", stats.Total))
+	if stats.Stale {
+		md.WriteString("**Status:** Stale (sources past their TTL)\n\n")
+	} else {
+		md.WriteString("**Status:** Fresh\n\n")
+	}
+	if stats.DiskSize > 0 {
+		md.WriteString(fmt.Sprintf("**Size on Disk:** %s\n\n", formatBytes(stats.DiskSize)))
 	}
 
 	// Top sources
 	md.WriteString("## Sources\n\n")
-	for source, count := range sourceCount {
-		md.WriteString(fmt.Sprintf("- **%s**: %d articles\n", source, count))
+	for _, source := range stats.Sources {
+		md.WriteString(fmt.Sprintf("- **%s**: %d articles\n", source.Name, source.Count))
 	}
 	md.WriteString("\n")
 
+	// Countries
+	if len(stats.Countries) > 0 {
+		md.WriteString("## Countries\n\n")
+		for _, country := range stats.Countries {
+			md.WriteString(fmt.Sprintf("- **%s**: %d articles\n", strings.ToUpper(country.Name), country.Count))
+		}
+		md.WriteString("\n")
+	}
+
 	// Categories
-	if len(categoryCount) > 0 {
+	if len(stats.Categories) > 0 {
 		md.WriteString("## Categories\n\n")
-		for category, count := range categoryCount {
-			md.WriteString(fmt.Sprintf("- **%s**: %d articles\n", category, count))
+		for _, category := range stats.Categories {
+			md.WriteString(fmt.Sprintf("- **%s**: %d articles\n", category.Name, category.Count))
 		}
 		md.WriteString("\n")
 	}
 
 	// Time range
-	if len(articles) > 0 {
-		oldest := articles[0].Published
-		newest := articles[0].Published
+	if stats.Newest != nil {
+		md.WriteString("## Time Range\n\n")
+		md.WriteString(fmt.Sprintf("- **Newest**: %s — %s (%s)\n", stats.Newest.Published.Format("January 2, 2006 at 15:04"), stats.Newest.Title, stats.Newest.Source))
+		md.WriteString(fmt.Sprintf("- **Oldest**: %s — %s (%s)\n\n", stats.Oldest.Published.Format("January 2, 2006 at 15:04"), stats.Oldest.Title, stats.Oldest.Source))
+
+		md.WriteString("## Publication\n\n")
+		md.WriteString("```\n" + publicationCharts(stats) + "```\n\n")
+	}
 
-		for _, article := range articles {
-			if article.Published.Before(oldest) {
-				oldest = article.Published
+	// Source health
+	if len(stats.Health) > 0 {
+		md.WriteString("## Source Health\n\n")
+		for _, health := range stats.Health {
+			icon := "✅"
+			if health.FailureStreak > 0 {
+				icon = "⚠️"
 			}
-			if article.Published.After(newest) {
-				newest = article.Published
+			md.WriteString(fmt.Sprintf("- %s **%s**: %s\n", icon, health.Name, healthLine(health)))
+			if health.FailureStreak > 0 && health.LastError != "" {
+				md.WriteString(fmt.Sprintf("  - %s\n", health.LastError))
 			}
 		}
-
-		md.WriteString("## Time Range\n\n")
-		md.WriteString(fmt.Sprintf("- **Newest**: %s\n", newest.Format("January 2, 2006 at 15:04")))
-		md.WriteString(fmt.Sprintf("- **Oldest**: %s\n", oldest.Format("January 2, 2006 at 15:04")))
 	}
 
 	return mr.glamour.Render(md.String())
 }
 
+// mediaLine describes an audio or video item with a link to it
+func mediaLine(media news.Media) string {
+	icon, label := "🎧", "Audio"
+	if media.Type == news.MediaVideo {
+		icon, label = "🎬", "Video"
+	}
+	if duration := media.DurationString(); duration != "" {
+		label += " • " + duration
+	}
+	return fmt.Sprintf("%s [%s](%s)", icon, label, media.URL)
+}
+
+// enclosureLabel describes an enclosure by type and size
+func enclosureLabel(enc news.Enclosure) string {
+	label := enc.Type
+	if label == "" {
+		label = "file"
+	}
+	if enc.Length > 0 {
+		label += fmt.Sprintf(" (%.1f MB)", float64(enc.Length)/(1024*1024))
+	}
+	return label
+}
+
 // formatTimeAgo formats time in a human-readable "time ago" format
 func formatTimeAgo(t time.Time) string {
 	now := time.Now()
@@ -0,0 +1,326 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/textutil"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// maxNewspaperWidth keeps very wide terminals readable
+	maxNewspaperWidth = 160
+	// columnGap is the space between newspaper columns
+	columnGap = 3
+	// minColumnWidth is the narrowest column before fewer columns are used
+	minColumnWidth = 34
+	// maxColumns is the most columns a front page uses
+	maxColumns = 3
+)
+
+// NewspaperRenderer lays out articles as a newspaper front page: a
+// masthead, a lead story across the page and the remaining stories in
+// columns, grouped into sections by category
+type NewspaperRenderer struct {
+	width int
+	now   time.Time
+
+	masthead lipgloss.Style
+	rule     lipgloss.Style
+	section  lipgloss.Style
+	headline lipgloss.Style
+	lead     lipgloss.Style
+	meta     lipgloss.Style
+	link     lipgloss.Style
+}
+
+// NewNewspaperRenderer creates a renderer for a terminal of the given width
+func NewNewspaperRenderer(width int) *NewspaperRenderer {
+	if width > maxNewspaperWidth {
+		width = maxNewspaperWidth
+	}
+	if width < 20 {
+		width = 20
+	}
+
+	return &NewspaperRenderer{
+		width:    width,
+		now:      time.Now(),
+		masthead: lipgloss.NewStyle().Bold(true),
+		rule:     lipgloss.NewStyle().Faint(true),
+		section:  lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF6B6B")),
+		headline: lipgloss.NewStyle().Bold(true),
+		lead:     lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFD93D")),
+		meta:     lipgloss.NewStyle().Italic(true).Faint(true),
+		link:     lipgloss.NewStyle().Foreground(lipgloss.Color("#5DADE2")),
+	}
+}
+
+// Columns returns how many story columns fit the page width
+func (nr *NewspaperRenderer) Columns() int {
+	columns := maxColumns
+	for columns > 1 && nr.columnWidth(columns) < minColumnWidth {
+		columns--
+	}
+	return columns
+}
+
+// columnWidth returns the width of each column when the page has n columns
+func (nr *NewspaperRenderer) columnWidth(n int) int {
+	return (nr.width - (n-1)*columnGap) / n
+}
+
+// RenderArticles renders the front page
+func (nr *NewspaperRenderer) RenderArticles(articles []news.Article, title string) string {
+	var b strings.Builder
+	b.WriteString(nr.renderMasthead(title, len(articles)))
+
+	if len(articles) == 0 {
+		b.WriteString("\n📭 No articles found\n")
+		return b.String()
+	}
+
+	lead := leadStory(articles)
+	b.WriteString("\n")
+	b.WriteString(nr.renderLead(articles[lead]))
+	b.WriteString("\n")
+
+	rest := make([]news.Article, 0, len(articles)-1)
+	rest = append(rest, articles[:lead]...)
+	rest = append(rest, articles[lead+1:]...)
+
+	for _, section := range groupSections(rest) {
+		b.WriteString("\n")
+		b.WriteString(nr.renderSectionHeader(section.name))
+		b.WriteString("\n\n")
+		b.WriteString(nr.renderColumns(section.articles))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(nr.rule.Render(strings.Repeat("─", nr.width)))
+	b.WriteString("\n")
+	b.WriteString(nr.meta.Render(fmt.Sprintf("%d stories • Generated with NWCLI", len(articles))))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderMasthead renders the paper's name with the date and edition
+func (nr *NewspaperRenderer) renderMasthead(title string, count int) string {
+	heavy := nr.rule.Render(strings.Repeat("━", nr.width))
+
+	name := lipgloss.PlaceHorizontal(nr.width, lipgloss.Center,
+		nr.masthead.Render(textutil.Truncate("📰 "+strings.ToUpper(title), nr.width)))
+
+	date := nr.now.Format("Monday, January 2, 2006")
+	edition := fmt.Sprintf("%s • %d stories", edition(nr.now), count)
+	dateline := date + strings.Repeat(" ", max(1, nr.width-textutil.Width(date)-textutil.Width(edition))) + edition
+	if textutil.Width(date)+textutil.Width(edition)+1 > nr.width {
+		dateline = lipgloss.PlaceHorizontal(nr.width, lipgloss.Center, date)
+	}
+
+	return heavy + "\n" + name + "\n" + heavy + "\n" + nr.meta.Render(dateline) + "\n" +
+		nr.rule.Render(strings.Repeat("─", nr.width)) + "\n"
+}
+
+// renderLead renders the lead story across the full page width
+func (nr *NewspaperRenderer) renderLead(article news.Article) string {
+	var b strings.Builder
+
+	b.WriteString(nr.lead.Render(textutil.Wrap(strings.ToUpper(article.Title), nr.width)))
+	b.WriteString("\n")
+	b.WriteString(nr.meta.Render(textutil.Wrap(storyMeta(article), nr.width)))
+	b.WriteString("\n")
+
+	if article.ImageURL != "" {
+		b.WriteString("\n")
+		b.WriteString(nr.link.Render(textutil.Truncate("🖼️  "+article.ImageURL, nr.width)))
+		b.WriteString("\n")
+	}
+
+	if text := storyText(article, 600); text != "" {
+		b.WriteString("\n")
+		// Wide pages set the lead text in two columns like the stories below
+		if nr.Columns() > 1 {
+			width := nr.columnWidth(2)
+			wrapped := strings.Split(textutil.Wrap(text, width), "\n")
+			half := (len(wrapped) + 1) / 2
+			left := lipgloss.NewStyle().Width(width + columnGap).Render(strings.Join(wrapped[:half], "\n"))
+			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Join(wrapped[half:], "\n")))
+		} else {
+			b.WriteString(textutil.Wrap(text, nr.width))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(nr.link.Render(textutil.Truncate("🔗 "+article.Link, nr.width)))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderSectionHeader renders a section name on a rule across the page
+func (nr *NewspaperRenderer) renderSectionHeader(name string) string {
+	label := " " + strings.ToUpper(name) + " "
+	rest := nr.width - textutil.Width(label) - 2
+	if rest < 0 {
+		rest = 0
+	}
+	return nr.rule.Render("━━") + nr.section.Render(label) + nr.rule.Render(strings.Repeat("━", rest))
+}
+
+// renderColumns distributes stories over the columns, always adding the
+// next story to the shortest column
+func (nr *NewspaperRenderer) renderColumns(articles []news.Article) string {
+	columns := nr.Columns()
+	width := nr.columnWidth(columns)
+
+	stacks := make([][]string, columns)
+	heights := make([]int, columns)
+	for _, article := range articles {
+		shortest := 0
+		for i := range heights {
+			if heights[i] < heights[shortest] {
+				shortest = i
+			}
+		}
+
+		story := nr.renderStory(article, width)
+		stacks[shortest] = append(stacks[shortest], story)
+		heights[shortest] += lipgloss.Height(story) + 1
+	}
+
+	blocks := make([]string, 0, columns)
+	for i, stack := range stacks {
+		if len(stack) == 0 {
+			continue
+		}
+		style := lipgloss.NewStyle().Width(width)
+		if i < columns-1 {
+			style = style.MarginRight(columnGap)
+		}
+		blocks = append(blocks, style.Render(strings.Join(stack, "\n\n")))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, blocks...)
+}
+
+// renderStory renders a secondary story for a column of the given width
+func (nr *NewspaperRenderer) renderStory(article news.Article, width int) string {
+	var b strings.Builder
+
+	b.WriteString(nr.headline.Render(textutil.Wrap(article.Title, width)))
+	b.WriteString("\n")
+	b.WriteString(nr.meta.Render(textutil.Wrap(storyMeta(article), width)))
+
+	if text := storyText(article, 240); text != "" {
+		b.WriteString("\n")
+		b.WriteString(textutil.Wrap(text, width))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(nr.link.Render(textutil.Truncate("🔗 "+article.Link, width)))
+
+	return b.String()
+}
+
+// section is a group of stories under a category header
+type section struct {
+	name     string
+	articles []news.Article
+}
+
+// groupSections groups stories by their first category, in order of first
+// appearance. Stories without a category are collected under "Latest",
+// and categories with a single story under "More News".
+func groupSections(articles []news.Article) []section {
+	var sections []section
+	index := make(map[string]int)
+
+	for _, article := range articles {
+		name := "Latest"
+		if len(article.Categories) > 0 && strings.TrimSpace(article.Categories[0]) != "" {
+			name = strings.TrimSpace(article.Categories[0])
+		}
+
+		key := strings.ToLower(name)
+		i, ok := index[key]
+		if !ok {
+			i = len(sections)
+			index[key] = i
+			sections = append(sections, section{name: name})
+		}
+		sections[i].articles = append(sections[i].articles, article)
+	}
+
+	// Collect sections with a single story at the end instead of giving
+	// each its own header
+	var grouped, singles []section
+	for _, section := range sections {
+		if len(section.articles) == 1 {
+			singles = append(singles, section)
+		} else {
+			grouped = append(grouped, section)
+		}
+	}
+	if len(singles) < 2 {
+		return sections
+	}
+
+	more := section{name: "More News"}
+	for _, single := range singles {
+		more.articles = append(more.articles, single.articles...)
+	}
+	return append(grouped, more)
+}
+
+// leadStory picks the front page story: the first of the newest few that
+// has an image and text, or simply the first
+func leadStory(articles []news.Article) int {
+	for i := 0; i < len(articles) && i < 5; i++ {
+		if articles[i].ImageURL != "" && storyText(articles[i], 1) != "" {
+			return i
+		}
+	}
+	return 0
+}
+
+// storyMeta describes a story's source, authors and age
+func storyMeta(article news.Article) string {
+	meta := article.Source
+	if authors := article.AuthorNames(); authors != "" {
+		meta += " • by " + authors
+	}
+	return meta + " • " + formatTimeAgo(article.Published)
+}
+
+// storyText returns the summary, description or content, truncated to
+// maxLen
+func storyText(article news.Article, maxLen int) string {
+	text := article.Summary
+	if text == "" {
+		text = article.Description
+	}
+	if text == "" {
+		text = article.Content
+	}
+	return textutil.Truncate(strings.Join(strings.Fields(text), " "), maxLen)
+}
+
+// edition names the edition by the time of day
+func edition(now time.Time) string {
+	switch hour := now.Hour(); {
+	case hour < 12:
+		return "Morning Edition"
+	case hour < 18:
+		return "Afternoon Edition"
+	default:
+		return "Evening Edition"
+	}
+}
@@ -0,0 +1,202 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/textutil"
+)
+
+func init() {
+	Register("plain", func() (Renderer, error) {
+		return PlainRenderer{color: colorOutput()}, nil
+	})
+}
+
+// PlainRenderer renders unstyled text. Search matches are highlighted with
+// ANSI colors when color is set.
+type PlainRenderer struct {
+	color bool
+}
+
+// RenderArticles renders articles separated by rules
+func (PlainRenderer) RenderArticles(articles []news.Article, title string) (string, error) {
+	var b strings.Builder
+
+	for i, article := range articles {
+		if i > 0 {
+			b.WriteString("\n" + strings.Repeat("-", 50) + "\n")
+		}
+
+		fmt.Fprintf(&b, "Title: %s\n", article.Title)
+		fmt.Fprintf(&b, "Source: %s\n", article.Source)
+		if authors := article.AuthorNames(); authors != "" {
+			fmt.Fprintf(&b, "Authors: %s\n", authors)
+		}
+		fmt.Fprintf(&b, "Published: %s\n", article.Published.Format("2006-01-02 15:04"))
+		if !article.Updated.IsZero() {
+			fmt.Fprintf(&b, "Updated: %s\n", article.Updated.Format("2006-01-02 15:04"))
+		}
+		if article.Summary != "" {
+			fmt.Fprintf(&b, "Summary: %s\n", article.Summary)
+		} else if article.Description != "" {
+			fmt.Fprintf(&b, "Description: %s\n", article.Description)
+		}
+		fmt.Fprintf(&b, "URL: %s\n", article.Link)
+		if article.CommentsURL != "" {
+			fmt.Fprintf(&b, "Comments: %s\n", article.CommentsURL)
+		}
+		for _, enc := range article.Enclosures {
+			fmt.Fprintf(&b, "Enclosure: %s (%s)\n", enc.URL, enc.Type)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// RenderSingleArticle renders an article with its full text
+func (PlainRenderer) RenderSingleArticle(article news.Article) (string, error) {
+	var b strings.Builder
+
+	b.WriteString(article.Title + "\n")
+	b.WriteString(strings.Repeat("=", textutil.Width(article.Title)) + "\n\n")
+	fmt.Fprintf(&b, "Source: %s\n", article.Source)
+	if authors := article.AuthorNames(); authors != "" {
+		fmt.Fprintf(&b, "By: %s\n", authors)
+	}
+	fmt.Fprintf(&b, "Published: %s\n", article.Published.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "URL: %s\n\n", article.Link)
+
+	if article.Summary != "" {
+		b.WriteString(textutil.Wrap(article.Summary, 80) + "\n\n")
+	}
+	text := article.Content
+	if text == "" {
+		text = article.Description
+	}
+	if text != "" {
+		b.WriteString(textutil.Wrap(text, 80) + "\n")
+	}
+
+	return b.String(), nil
+}
+
+// RenderSources renders sources grouped by category
+func (PlainRenderer) RenderSources(sources []news.Source) (string, error) {
+	var b strings.Builder
+
+	// Determine country name from sources
+	countryName := "News Sources"
+	if len(sources) > 0 {
+		switch sources[0].Language {
+		case "nl":
+			countryName = "Dutch News Sources"
+		case "en":
+			countryName = "English News Sources"
+		case "de":
+			countryName = "German News Sources"
+		case "fr":
+			countryName = "French News Sources"
+		}
+	}
+
+	fmt.Fprintf(&b, "Available %s:\n", countryName)
+	b.WriteString(strings.Repeat("=", textutil.Width(countryName)+11) + "\n")
+
+	// Group by category, in order of first appearance
+	var order []string
+	categories := make(map[string][]news.Source)
+	for _, source := range sources {
+		if _, ok := categories[source.Category]; !ok {
+			order = append(order, source.Category)
+		}
+		categories[source.Category] = append(categories[source.Category], source)
+	}
+
+	for _, category := range order {
+		fmt.Fprintf(&b, "\n%s:\n", category)
+		b.WriteString(strings.Repeat("-", textutil.Width(category)+1) + "\n")
+
+		for _, source := range categories[category] {
+			fmt.Fprintf(&b, "• %s\n", source.Name)
+			fmt.Fprintf(&b, "  %s\n", source.Description)
+			fmt.Fprintf(&b, "  URL: %s\n", source.URL)
+			fmt.Fprintf(&b, "  Language: %s\n\n", source.Language)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// RenderCountries renders the supported countries
+func (PlainRenderer) RenderCountries(countries []news.Country) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("Supported Countries:\n")
+	b.WriteString("==================\n")
+	for _, country := range countries {
+		fmt.Fprintf(&b, "  %s - %s (%s)\n", country.Code, country.Name, country.Language)
+	}
+	b.WriteString("\nUsage: Use the country code with --country flag\n")
+	b.WriteString("Example: nwcli latest --country us --limit 10\n")
+
+	return b.String(), nil
+}
+
+// RenderStats renders statistics as a list
+func (PlainRenderer) RenderStats(stats Stats) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("Cache Statistics\n")
+	b.WriteString("================\n")
+	fmt.Fprintf(&b, "Total articles: %d\n", stats.Total)
+	if stats.Stale {
+		b.WriteString("Status: Stale (sources past their TTL)\n")
+	} else {
+		b.WriteString("Status: Fresh\n")
+	}
+
+	if stats.DiskSize > 0 {
+		fmt.Fprintf(&b, "Size on disk: %s\n", formatBytes(stats.DiskSize))
+	}
+	if stats.Newest != nil {
+		fmt.Fprintf(&b, "Newest: %s %s (%s)\n", stats.Newest.Published.Format("2006-01-02 15:04"), stats.Newest.Title, stats.Newest.Source)
+		fmt.Fprintf(&b, "Oldest: %s %s (%s)\n", stats.Oldest.Published.Format("2006-01-02 15:04"), stats.Oldest.Title, stats.Oldest.Source)
+	}
+
+	b.WriteString("\nArticles by source:\n")
+	for _, source := range stats.Sources {
+		fmt.Fprintf(&b, "  %s: %d\n", source.Name, source.Count)
+	}
+
+	if len(stats.Countries) > 0 {
+		b.WriteString("\nArticles by country:\n")
+		for _, country := range stats.Countries {
+			fmt.Fprintf(&b, "  %s: %d\n", country.Name, country.Count)
+		}
+	}
+
+	if len(stats.Categories) > 0 {
+		b.WriteString("\nArticles by category:\n")
+		for _, category := range stats.Categories {
+			fmt.Fprintf(&b, "  %s: %d\n", category.Name, category.Count)
+		}
+	}
+
+	if stats.Newest != nil {
+		b.WriteString("\n" + publicationCharts(stats))
+	}
+
+	if len(stats.Health) > 0 {
+		b.WriteString("\nSource health:\n")
+		for _, health := range stats.Health {
+			fmt.Fprintf(&b, "  %s: %s\n", health.Name, healthLine(health))
+			if health.FailureStreak > 0 && health.LastError != "" {
+				fmt.Fprintf(&b, "    last error: %s\n", health.LastError)
+			}
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,243 @@
+package renderer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+)
+
+// Renderer turns news data into one output format
+type Renderer interface {
+	RenderArticles(articles []news.Article, title string) (string, error)
+	RenderSingleArticle(article news.Article) (string, error)
+	RenderSources(sources []news.Source) (string, error)
+	RenderCountries(countries []news.Country) (string, error)
+	RenderStats(stats Stats) (string, error)
+	RenderSearchResults(results []news.SearchResult, title string) (string, error)
+}
+
+// Factory creates a renderer
+type Factory func() (Renderer, error)
+
+// registry holds the renderer of each format by name
+var registry = make(map[string]Factory)
+
+// Register makes a format available under name. It panics when the name is
+// taken, since formats register during package initialization.
+func Register(name string, factory Factory) {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("renderer: format %q registered twice", name))
+	}
+	registry[name] = factory
+}
+
+// Formats returns the names of the registered formats
+func Formats() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckFormat returns an error listing the available formats when format
+// is not registered
+func CheckFormat(format string) error {
+	if _, ok := registry[format]; !ok {
+		return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return nil
+}
+
+// New creates the renderer of a format
+func New(format string) (Renderer, error) {
+	if err := CheckFormat(format); err != nil {
+		return nil, err
+	}
+	return registry[format]()
+}
+
+// statsDays is the number of days in the publication histogram by day
+const statsDays = 14
+
+// Stats summarizes a set of articles
+type Stats struct {
+	Total int `json:"total"`
+	// Stale is set when a cached source is past its TTL
+	Stale    bool  `json:"stale"`
+	DiskSize int64 `json:"disk_size,omitempty"`
+	// Oldest and Newest are the articles published first and last
+	Oldest     *ArticleRef `json:"oldest,omitempty"`
+	Newest     *ArticleRef `json:"newest,omitempty"`
+	Sources    []Count     `json:"sources"`
+	Countries  []Count     `json:"countries,omitempty"`
+	Categories []Count     `json:"categories,omitempty"`
+	// ByHour counts the articles published in each hour of the day, in
+	// local time
+	ByHour []int `json:"by_hour"`
+	// ByDay counts the articles published on each of the last days up to
+	// the newest article, oldest first
+	ByDay  []DayCount     `json:"by_day"`
+	Health []SourceHealth `json:"health,omitempty"`
+}
+
+// Count is the number of articles with a source, country or category
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// DayCount is the number of articles published on a day
+type DayCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// ArticleRef identifies an article in statistics
+type ArticleRef struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Source    string    `json:"source"`
+	Published time.Time `json:"published"`
+}
+
+// SourceHealth is the fetch history of a source
+type SourceHealth struct {
+	Name          string    `json:"name"`
+	Articles      int       `json:"articles"`
+	LastSuccess   time.Time `json:"last_success,omitzero"`
+	LastAttempt   time.Time `json:"last_attempt,omitzero"`
+	LastError     string    `json:"last_error,omitempty"`
+	FailureStreak int       `json:"failure_streak"`
+	Fetches       int       `json:"fetches"`
+	AverageItems  float64   `json:"average_items"`
+	// TTLSeconds is how long the source stays fresh after a fetch, and
+	// State is fresh, stale or expired
+	TTLSeconds int    `json:"ttl_seconds"`
+	State      string `json:"state"`
+}
+
+// NewStats computes statistics over articles
+func NewStats(articles []news.Article) Stats {
+	stats := Stats{Total: len(articles), ByHour: make([]int, 24)}
+
+	sources := make(map[string]int)
+	countries := make(map[string]int)
+	categories := make(map[string]int)
+	days := make(map[string]int)
+	var oldest, newest *news.Article
+	for i, article := range articles {
+		sources[article.Source]++
+		for _, category := range article.Categories {
+			categories[category]++
+		}
+
+		if article.Published.IsZero() {
+			continue
+		}
+		published := article.Published.Local()
+		stats.ByHour[published.Hour()]++
+		days[published.Format("2006-01-02")]++
+
+		if oldest == nil || article.Published.Before(oldest.Published) {
+			oldest = &articles[i]
+		}
+		if newest == nil || article.Published.After(newest.Published) {
+			newest = &articles[i]
+		}
+	}
+
+	for source, count := range sources {
+		country := news.CountryForSource(source)
+		if country == "" {
+			country = "other"
+		}
+		countries[country] += count
+	}
+
+	stats.Sources = sortedCounts(sources)
+	stats.Countries = sortedCounts(countries)
+	stats.Categories = sortedCounts(categories)
+	stats.Oldest = articleRef(oldest)
+	stats.Newest = articleRef(newest)
+
+	if newest != nil {
+		last := newest.Published.Local()
+		for i := statsDays - 1; i >= 0; i-- {
+			date := last.AddDate(0, 0, -i).Format("2006-01-02")
+			stats.ByDay = append(stats.ByDay, DayCount{Date: date, Count: days[date]})
+		}
+	}
+	return stats
+}
+
+// sourceStates names the freshness states of sources
+var sourceStates = map[news.SourceState]string{
+	news.SourceFresh:   "fresh",
+	news.SourceStale:   "stale",
+	news.SourceExpired: "expired",
+}
+
+// AddHealth adds the fetch history and freshness of every source fetched
+// so far, marking the stats stale when a cached source is past its TTL
+func (s *Stats) AddHealth(states map[string]news.FeedState, freshness news.Freshness) {
+	articles := make(map[string]int)
+	for _, source := range s.Sources {
+		articles[source.Name] = source.Count
+	}
+
+	s.Health = s.Health[:0]
+	for name, state := range states {
+		sourceState := freshness.State(name, state)
+		if sourceState != news.SourceFresh && articles[name] > 0 {
+			s.Stale = true
+		}
+		s.Health = append(s.Health, SourceHealth{
+			Name:          name,
+			Articles:      articles[name],
+			LastSuccess:   state.LastSuccess,
+			LastAttempt:   state.LastAttempt,
+			LastError:     state.LastError,
+			FailureStreak: state.FailureStreak,
+			Fetches:       state.Fetches,
+			AverageItems:  state.AverageItems(),
+			TTLSeconds:    int(freshness.TTLFor(name, state).Seconds()),
+			State:         sourceStates[sourceState],
+		})
+	}
+	sort.Slice(s.Health, func(i, j int) bool {
+		return s.Health[i].Name < s.Health[j].Name
+	})
+}
+
+// articleRef returns a reference to article, or nil
+func articleRef(article *news.Article) *ArticleRef {
+	if article == nil {
+		return nil
+	}
+	return &ArticleRef{
+		ID:        article.ID(),
+		Title:     article.Title,
+		Source:    article.Source,
+		Published: article.Published,
+	}
+}
+
+// sortedCounts orders counts from most to fewest articles, then by name
+func sortedCounts(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, Count{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
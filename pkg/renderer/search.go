@@ -0,0 +1,154 @@
+package renderer
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+
+	"golang.org/x/term"
+)
+
+// ANSI escapes around highlighted matches in plain output
+const (
+	ansiMatch = "\x1b[1;33m"
+	ansiReset = "\x1b[0m"
+)
+
+// RenderSearchResults renders matching articles with the matched fields
+// and snippets, the matches in code spans
+func (mr *MarkdownRenderer) RenderSearchResults(results []news.SearchResult, title string) (string, error) {
+	if len(results) == 0 {
+		return mr.RenderMessage("🔍 No matches", "Try a different search query or check your sources.")
+	}
+
+	var md strings.Builder
+
+	md.WriteString(fmt.Sprintf("# 🔍 %s\n\n", title))
+	md.WriteString(fmt.Sprintf("*Updated: %s*\n\n", time.Now().Format("Monday, January 2, 2006 at 15:04")))
+	md.WriteString("---\n\n")
+
+	for i, result := range results {
+		if i > 0 {
+			md.WriteString("\n---\n\n")
+		}
+
+		md.WriteString(fmt.Sprintf("## %s\n\n", markdownHighlight(result.TitleSnippet())))
+		md.WriteString(fmt.Sprintf("*%s • %s*\n\n", result.Source, formatTimeAgo(result.Published)))
+
+		if fields := result.Fields(); len(fields) > 0 {
+			md.WriteString(fmt.Sprintf("**Matched in:** %s\n\n", strings.Join(fields, ", ")))
+		}
+		for _, snippet := range result.Snippets() {
+			md.WriteString(fmt.Sprintf("> %s\n\n", markdownHighlight(snippet)))
+		}
+
+		md.WriteString(fmt.Sprintf("🔗 [Read full article](%s)\n\n", result.Link))
+	}
+
+	md.WriteString("---\n\n")
+	md.WriteString(fmt.Sprintf("*Found %d articles • Generated with NWCLI*\n", len(results)))
+
+	return mr.glamour.Render(md.String())
+}
+
+// markdownHighlight puts the matches of a snippet in code spans, which
+// stand out in headings and quotes alike
+func markdownHighlight(snippet news.Snippet) string {
+	var b strings.Builder
+	for _, segment := range snippet.Segments() {
+		if segment.Match {
+			b.WriteString("`" + strings.ReplaceAll(segment.Text, "`", "'") + "`")
+		} else {
+			b.WriteString(segment.Text)
+		}
+	}
+	return b.String()
+}
+
+// RenderSearchResults renders matching articles with the matched fields
+// and snippets. Matches are bold yellow on a terminal.
+func (pr PlainRenderer) RenderSearchResults(results []news.SearchResult, title string) (string, error) {
+	var b strings.Builder
+
+	for i, result := range results {
+		if i > 0 {
+			b.WriteString("\n" + strings.Repeat("-", 50) + "\n")
+		}
+
+		fmt.Fprintf(&b, "Title: %s\n", pr.highlight(result.TitleSnippet()))
+		fmt.Fprintf(&b, "Source: %s\n", result.Source)
+		fmt.Fprintf(&b, "Published: %s\n", result.Published.Format("2006-01-02 15:04"))
+		if fields := result.Fields(); len(fields) > 0 {
+			fmt.Fprintf(&b, "Matched: %s\n", strings.Join(fields, ", "))
+		}
+		for _, snippet := range result.Snippets() {
+			fmt.Fprintf(&b, "  %s\n", pr.highlight(snippet))
+		}
+		fmt.Fprintf(&b, "URL: %s\n", result.Link)
+	}
+
+	return b.String(), nil
+}
+
+// highlight returns the text of a snippet, with ANSI highlights when
+// writing to a terminal
+func (pr PlainRenderer) highlight(snippet news.Snippet) string {
+	if !pr.color {
+		return snippet.Text
+	}
+	return snippet.Highlight(ansiMatch, ansiReset)
+}
+
+// colorOutput reports whether plain output goes to a terminal that
+// accepts colors
+func colorOutput() bool {
+	return term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == ""
+}
+
+// RenderSearchResults renders matching articles as an array, or one per
+// line, each with a matches array giving the matched fields, the match
+// positions in characters and snippets
+func (jr JSONRenderer) RenderSearchResults(results []news.SearchResult, title string) (string, error) {
+	return renderJSONList(jr, results)
+}
+
+// searchHeader lists the extra columns of search results
+var searchHeader = []string{"matched_fields", "snippet"}
+
+// RenderSearchResults renders one row per article with the matched fields
+// and the first snippet
+func (cr CSVRenderer) RenderSearchResults(results []news.SearchResult, title string) (string, error) {
+	rows := make([][]string, 0, len(results)+1)
+	rows = append(rows, append(append([]string{}, ArticleColumns...), searchHeader...))
+	for _, result := range results {
+		snippet := ""
+		if snippets := result.Snippets(); len(snippets) > 0 {
+			snippet = snippets[0].Text
+		}
+		rows = append(rows, append(articleRow(result.Article), strings.Join(result.Fields(), "; "), snippet))
+	}
+	return cr.render(rows)
+}
+
+// RenderSearchResults renders matching articles as cards with the matches
+// marked
+func (hr HTMLRenderer) RenderSearchResults(results []news.SearchResult, title string) (string, error) {
+	return hr.render("search", title, results)
+}
+
+// markHTML escapes the text of a snippet and wraps its matches in <mark>
+func markHTML(snippet news.Snippet) template.HTML {
+	var b strings.Builder
+	for _, segment := range snippet.Segments() {
+		text := template.HTMLEscapeString(segment.Text)
+		if segment.Match {
+			text = "<mark>" + text + "</mark>"
+		}
+		b.WriteString(text)
+	}
+	return template.HTML(b.String())
+}
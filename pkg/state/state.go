@@ -0,0 +1,117 @@
+// Package state persists the reader's state between TUI sessions: when the
+// last session ended, the pane sizes and how far articles were read.
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"nwcli/pkg/config"
+)
+
+// maxPositions bounds the reading positions kept between sessions
+const maxPositions = 500
+
+// Session is TUI state persisted between sessions
+type Session struct {
+	LastSession time.Time `json:"last_session"`
+	// SplitRatio is the share of the width given to the list in the split
+	// layout
+	SplitRatio float64 `json:"split_ratio,omitempty"`
+	// Positions are the reading positions of recently read articles
+	Positions map[string]ReadPosition `json:"positions,omitempty"`
+}
+
+// ReadPosition is how far an article was read
+type ReadPosition struct {
+	// Progress is the share of the article's lines above the viewport
+	Progress float64   `json:"progress"`
+	Read     time.Time `json:"read"`
+}
+
+// statePath returns the location of the TUI state file
+func statePath() string {
+	return filepath.Join(config.Dir(), "tui_state.json")
+}
+
+// Load reads the persisted TUI state, returning an empty state if none
+// exists
+func Load() Session {
+	var session Session
+
+	data, err := os.ReadFile(statePath())
+	if err != nil {
+		return session
+	}
+
+	json.Unmarshal(data, &session)
+	return session
+}
+
+// Save writes the TUI state to disk, keeping only the most recently read
+// positions
+func Save(session Session) error {
+	if err := os.MkdirAll(filepath.Dir(statePath()), 0755); err != nil {
+		return err
+	}
+
+	session.Positions = prunePositions(session.Positions)
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(statePath(), data, 0644)
+}
+
+// ReadPositions returns the saved reading positions by article key
+func ReadPositions() map[string]ReadPosition {
+	return Load().Positions
+}
+
+// MergeReadPositions adds reading positions from another machine; for
+// each article the position read last wins. It returns the number of
+// positions added or changed.
+func MergeReadPositions(positions map[string]ReadPosition) (int, error) {
+	session := Load()
+	if session.Positions == nil {
+		session.Positions = make(map[string]ReadPosition)
+	}
+
+	changed := 0
+	for key, position := range positions {
+		if position.Read.After(session.Positions[key].Read) {
+			session.Positions[key] = position
+			changed++
+		}
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+
+	return changed, Save(session)
+}
+
+// prunePositions keeps the most recently read positions
+func prunePositions(positions map[string]ReadPosition) map[string]ReadPosition {
+	if len(positions) <= maxPositions {
+		return positions
+	}
+
+	keys := make([]string, 0, len(positions))
+	for key := range positions {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return positions[keys[i]].Read.After(positions[keys[j]].Read)
+	})
+
+	pruned := make(map[string]ReadPosition, maxPositions)
+	for _, key := range keys[:maxPositions] {
+		pruned[key] = positions[key]
+	}
+	return pruned
+}
@@ -0,0 +1,127 @@
+package summary
+
+import (
+	"strings"
+	"unicode"
+)
+
+// abbreviations lists lowercase tokens (without the final period) that do
+// not end a sentence, per language
+var abbreviations = map[string][]string{
+	"en": {"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "vs", "etc", "e.g", "i.e", "inc", "ltd", "co", "corp", "no", "approx", "gen", "gov", "sen", "rep", "jan", "feb", "mar", "apr", "aug", "sep", "sept", "oct", "nov", "dec", "u.s", "u.k"},
+	"nl": {"dhr", "mevr", "mr", "dr", "prof", "ir", "ing", "drs", "bijv", "bv", "b.v", "o.a", "d.w.z", "m.a.w", "i.p.v", "t.o.v", "ca", "nr", "jl", "z.g.a.n", "enz", "etc", "blz", "n.a.v", "m.b.t", "e.d", "zgn"},
+	"de": {"hr", "fr", "dr", "prof", "z.b", "bzw", "usw", "u.a", "d.h", "ca", "nr", "str", "vgl", "evtl", "ggf", "inkl", "z.t", "u.s.w", "s", "sog", "mio", "mrd", "jh"},
+	"fr": {"m", "mm", "mme", "mlle", "dr", "pr", "me", "etc", "p.ex", "cf", "env", "av", "bd", "n°", "no", "st", "ste", "mgr", "c.-à-d"},
+}
+
+// SplitSentences splits text into sentences using language-aware rules for
+// abbreviations, initials and numbers. Line breaks always end a sentence.
+func SplitSentences(text, language string) []string {
+	language = normalizeLanguage(language)
+
+	abbrev := make(map[string]bool)
+	for _, a := range abbreviations[language] {
+		abbrev[a] = true
+	}
+
+	var sentences []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		sentences = append(sentences, splitParagraph(paragraph, abbrev, language == "de")...)
+	}
+
+	return sentences
+}
+
+// splitParagraph splits a single paragraph into sentences. When ordinals is
+// set, short numbers followed by a period ("15. März") do not end a sentence.
+func splitParagraph(paragraph string, abbrev map[string]bool, ordinals bool) []string {
+	runes := []rune(strings.TrimSpace(paragraph))
+	var sentences []string
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '.' && r != '!' && r != '?' && r != '…' {
+			continue
+		}
+
+		// Include trailing terminators and closing quotes/brackets
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(".!?…\"'”’»)]", runes[end]) {
+			end++
+		}
+
+		// A sentence boundary needs whitespace followed by a plausible start
+		next := end
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+		if next == end && end < len(runes) {
+			continue
+		}
+		if next < len(runes) && !isSentenceStart(runes[next]) {
+			continue
+		}
+
+		if r == '.' && isAbbreviation(runes[start:i], abbrev, ordinals) {
+			continue
+		}
+
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = next
+		i = next - 1
+	}
+
+	if start < len(runes) {
+		if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	return sentences
+}
+
+// isSentenceStart reports whether r can begin a new sentence
+func isSentenceStart(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsDigit(r) ||
+		strings.ContainsRune("\"'“‘«([", r)
+}
+
+// isAbbreviation reports whether the word before a period is an
+// abbreviation, an initial or (when ordinals is set) an ordinal number
+func isAbbreviation(before []rune, abbrev map[string]bool, ordinals bool) bool {
+	wordStart := len(before)
+	for wordStart > 0 && !unicode.IsSpace(before[wordStart-1]) {
+		wordStart--
+	}
+	word := strings.ToLower(strings.TrimLeft(string(before[wordStart:]), "(\"'“‘«"))
+
+	if word == "" {
+		return false
+	}
+
+	// Single letters are initials, as in "J. de Vries"
+	if len([]rune(word)) == 1 && unicode.IsLetter([]rune(word)[0]) {
+		return true
+	}
+
+	if ordinals && len(word) <= 2 && strings.Trim(word, "0123456789") == "" {
+		return true
+	}
+
+	return abbrev[word]
+}
+
+// normalizeLanguage maps a language tag such as "nl-NL" to its base code
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(language)
+	if idx := strings.IndexAny(language, "-_"); idx >= 0 {
+		language = language[:idx]
+	}
+	if _, ok := abbreviations[language]; !ok {
+		return "en"
+	}
+	return language
+}
@@ -0,0 +1,38 @@
+package summary
+
+import "strings"
+
+// stopwordLists holds common function words per language, ignored when
+// comparing sentences
+var stopwordLists = map[string]string{
+	"en": `a about above after again against all also am an and any are as at be because been before being below
+between both but by can could did do does doing down during each few for from further had has have having he her
+here hers him his how i if in into is it its itself just me more most my no nor not now of off on once only or other
+our ours out over own said same says she should so some such than that the their theirs them then there these they
+this those through to too under until up very was we were what when where which while who whom why will with would
+you your yours`,
+	"nl": `aan al alles als altijd andere ben bij daar dan dat de der deze die dit doch doen door dus een eens en er ge
+geen geweest haar had heb hebben heeft hem het hier hij hoe hun iemand iets ik in is ja je kan kon kunnen maar me meer
+men met mij mijn moet na naar niet niets nog nu of om omdat onder ons ook op over reeds te tegen toch toen tot u uit
+uw van veel voor want waren was wat werd wel wie wij wordt worden zal ze zei zelf zich zij zijn zo zonder zou`,
+	"de": `aber alle allem allen aller alles als also am an ander andere auch auf aus bei bin bis bist da damit dann das
+dass dein deine dem den der des dich die dies diese dieser dieses dir doch dort du durch ein eine einem einen einer
+eines er es etwas euer für gegen gewesen hab habe haben hat hatte hier hin hinter ich ihm ihn ihr ihre im in indem
+ins ist jede jedem jeden jeder jetzt kann kein keine können machen man mein meine mit muss nach nicht nichts noch
+nun nur ob oder ohne sehr sein seine sich sie sind so solche soll sondern sonst über um und uns unser unter viel vom
+von vor war waren warum was weil welche wenn werde werden wie wieder will wir wird wo wollen worden zu zum zur zwar`,
+	"fr": `à ai aie ainsi alors au aucun aussi autre aux avec avoir bon car ce cela celle celui ces cet cette ceux chaque
+ci comme comment dans de des depuis deux doit donc du elle elles en encore est et été être eu fait faire il ils je
+la le les leur leurs lui ma mais me même mes moi mon ne ni nos notre nous on ont ou où par pas peu peut plus pour
+pourquoi qu quand que quel quelle quels qui sa sans se selon ses si son sont sous sur ta te tes toi ton tous tout
+très tu un une vos votre vous y`,
+}
+
+// stopwordsFor returns the stopword set for a language
+func stopwordsFor(language string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(stopwordLists[normalizeLanguage(language)]) {
+		set[word] = true
+	}
+	return set
+}
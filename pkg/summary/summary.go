@@ -0,0 +1,173 @@
+package summary
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"nwcli/pkg/news"
+)
+
+// TextRank parameters
+const (
+	damping    = 0.85
+	iterations = 30
+	// minSentenceWords skips fragments such as captions and bylines
+	minSentenceWords = 4
+)
+
+// Summarize returns the n most central sentences of text, in their
+// original order, using TextRank over sentence word overlap. Language is
+// an ISO 639-1 code (nl, en, de, fr) used for sentence splitting and
+// stopwords; unknown languages fall back to English rules.
+func Summarize(text, language string, n int) string {
+	sentences := SplitSentences(text, language)
+	if n <= 0 || len(sentences) == 0 {
+		return ""
+	}
+	if len(sentences) <= n {
+		return strings.Join(sentences, " ")
+	}
+
+	stopwords := stopwordsFor(language)
+	words := make([][]string, len(sentences))
+	for i, sentence := range sentences {
+		words[i] = contentWords(sentence, stopwords)
+	}
+
+	scores := rank(words)
+
+	// Pick the top n candidates, preferring earlier sentences on ties
+	order := make([]int, len(sentences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+
+	var picked []int
+	for _, idx := range order {
+		if len(picked) >= n {
+			break
+		}
+		if len(words[idx]) < minSentenceWords && len(sentences) > n*2 {
+			continue
+		}
+		picked = append(picked, idx)
+	}
+	sort.Ints(picked)
+
+	result := make([]string, len(picked))
+	for i, idx := range picked {
+		result[i] = sentences[idx]
+	}
+
+	return strings.Join(result, " ")
+}
+
+// SummarizeArticle summarizes the longest text available for an article.
+// It returns "" when that text has no more than n sentences, as with the
+// teaser feeds carry without --full, or when the summary would only repeat
+// the description, so renderers fall back to the description.
+func SummarizeArticle(article news.Article, n int) string {
+	text := article.Content
+	if len(article.Description) > len(text) {
+		text = article.Description
+	}
+	if n <= 0 || len(SplitSentences(text, article.Language)) <= n {
+		return ""
+	}
+
+	summary := Summarize(text, article.Language, n)
+	if summary == strings.Join(strings.Fields(article.Description), " ") {
+		return ""
+	}
+	return summary
+}
+
+// rank runs TextRank over the sentence graph and returns a score per sentence
+func rank(words [][]string) []float64 {
+	count := len(words)
+
+	weights := make([][]float64, count)
+	totals := make([]float64, count)
+	for i := range weights {
+		weights[i] = make([]float64, count)
+	}
+	for i := 0; i < count; i++ {
+		for j := i + 1; j < count; j++ {
+			w := similarity(words[i], words[j])
+			weights[i][j] = w
+			weights[j][i] = w
+			totals[i] += w
+			totals[j] += w
+		}
+	}
+
+	scores := make([]float64, count)
+	for i := range scores {
+		scores[i] = 1
+	}
+
+	for iter := 0; iter < iterations; iter++ {
+		next := make([]float64, count)
+		for i := 0; i < count; i++ {
+			sum := 0.0
+			for j := 0; j < count; j++ {
+				if weights[j][i] > 0 && totals[j] > 0 {
+					sum += weights[j][i] / totals[j] * scores[j]
+				}
+			}
+			next[i] = (1 - damping) + damping*sum
+		}
+		scores = next
+	}
+
+	return scores
+}
+
+// similarity is the TextRank overlap measure between two sentences
+func similarity(a, b []string) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, word := range a {
+		set[word] = true
+	}
+
+	overlap := 0
+	seen := make(map[string]bool, len(b))
+	for _, word := range b {
+		if set[word] && !seen[word] {
+			overlap++
+			seen[word] = true
+		}
+	}
+
+	if overlap == 0 {
+		return 0
+	}
+
+	return float64(overlap) / (math.Log(float64(len(a))) + math.Log(float64(len(b))))
+}
+
+// contentWords lowercases a sentence and returns its non-stopword tokens
+func contentWords(sentence string, stopwords map[string]bool) []string {
+	fields := strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var words []string
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || stopwords[field] {
+			continue
+		}
+		words = append(words, field)
+	}
+
+	return words
+}
@@ -0,0 +1,153 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// Ellipsis is appended to truncated text
+const Ellipsis = "..."
+
+// Width returns the display width of s in terminal cells, counting wide
+// characters (CJK, most emoji) as two cells and combining marks as zero
+func Width(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// Truncate shortens s to at most maxWidth display cells, including the
+// ellipsis. It never splits a grapheme cluster and prefers to cut at a word
+// boundary when one is reasonably close to the limit.
+func Truncate(s string, maxWidth int) string {
+	if maxWidth <= 0 {
+		return ""
+	}
+	if Width(s) <= maxWidth {
+		return s
+	}
+
+	ellipsisWidth := Width(Ellipsis)
+	if maxWidth <= ellipsisWidth {
+		return cut(s, maxWidth)
+	}
+
+	budget := maxWidth - ellipsisWidth
+	head := cut(s, budget)
+
+	// Back off to the last word boundary unless that loses too much text
+	if idx := strings.LastIndexFunc(head, unicode.IsSpace); idx > 0 && Width(head[:idx]) >= budget/2 {
+		// Only back off when we actually cut inside a word
+		if next := s[len(head):]; next != "" && !startsWithSpace(next) {
+			head = head[:idx]
+		}
+	}
+
+	head = strings.TrimRightFunc(head, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ';' || r == ':' || r == '-'
+	})
+
+	return head + Ellipsis
+}
+
+// cut returns the longest prefix of s that fits in maxWidth cells without
+// splitting a grapheme cluster
+func cut(s string, maxWidth int) string {
+	width := 0
+	state := -1
+	rest := s
+	for len(rest) > 0 {
+		var cluster string
+		var clusterWidth int
+		cluster, rest, clusterWidth, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if width+clusterWidth > maxWidth {
+			return s[:len(s)-len(rest)-len(cluster)]
+		}
+		width += clusterWidth
+	}
+	return s
+}
+
+// startsWithSpace reports whether s begins with whitespace
+func startsWithSpace(s string) bool {
+	for _, r := range s {
+		return unicode.IsSpace(r)
+	}
+	return false
+}
+
+// Wrap word-wraps s to lines of at most width display cells. Existing line
+// breaks are kept; words longer than width are broken at grapheme boundaries.
+func Wrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		lines = append(lines, wrapParagraph(paragraph, width)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// wrapParagraph wraps a single line of text
+func wrapParagraph(paragraph string, width int) []string {
+	words := strings.Fields(paragraph)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+
+	for _, word := range words {
+		wordWidth := Width(word)
+
+		// Break words that can never fit on a line
+		for wordWidth > width {
+			if lineWidth > 0 {
+				lines = append(lines, line.String())
+				line.Reset()
+				lineWidth = 0
+			}
+			head := cut(word, width)
+			if head == "" {
+				break
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+			wordWidth = Width(word)
+		}
+		if word == "" {
+			continue
+		}
+
+		if lineWidth > 0 && lineWidth+1+wordWidth > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+		if lineWidth > 0 {
+			line.WriteByte(' ')
+			lineWidth++
+		}
+		line.WriteString(word)
+		lineWidth += wordWidth
+	}
+
+	if lineWidth > 0 {
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+// PadRight pads s with spaces to the given display width
+func PadRight(s string, width int) string {
+	if w := Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
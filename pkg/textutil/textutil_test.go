@@ -0,0 +1,115 @@
+package textutil
+
+import "testing"
+
+const family = "👨‍👩‍👧" // three emoji joined by zero width joiners
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "abc", 3},
+		{"cjk", "日本語", 6},
+		{"emoji", "👍", 2},
+		{"emoji with skin tone", "👍🏽", 2},
+		{"zwj sequence", family, 2},
+		{"flag", "🇳🇱", 2},
+		{"combining mark", "e\u0301", 1},
+		{"mixed", "Nieuws 日本 👍", 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Width(tt.s); got != tt.want {
+				t.Errorf("Width(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxWidth int
+		want     string
+	}{
+		{"fits", "hello", 10, "hello"},
+		{"exact fit", "hello", 5, "hello"},
+		{"zero width", "hello", 0, ""},
+		{"no room for ellipsis", "hello", 3, "hel"},
+		{"cut at space", "hello world", 8, "hello..."},
+		{"back off to word boundary", "the quick brown fox", 14, "the quick..."},
+		{"trailing punctuation", "one, two, three", 7, "one..."},
+		{"cjk", "日本語テキスト", 9, "日本語..."},
+		{"cjk never half a character", "日本語テキスト", 8, "日本..."},
+		{"cjk without ellipsis", "日本語", 3, "日"},
+		{"emoji", "👍👍👍👍", 7, "👍👍..."},
+		{"zwj sequence kept whole", family + family + family, 5, family + "..."},
+		{"zwj sequence that does not fit", family + family, 1, ""},
+		{"combining mark kept with its base", "cafe\u0301 au lait", 7, "cafe\u0301..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.s, tt.maxWidth)
+			if got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.maxWidth, got, tt.want)
+			}
+			if w := Width(got); w > tt.maxWidth {
+				t.Errorf("Truncate(%q, %d) is %d cells wide", tt.s, tt.maxWidth, w)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "short", 10, "short"},
+		{"zero width", "abc def", 0, "abc def"},
+		{"words", "the quick brown fox", 10, "the quick\nbrown fox"},
+		{"collapses spaces", "a   b", 10, "a b"},
+		{"keeps line breaks", "a\n\nb", 5, "a\n\nb"},
+		{"long word", "abcdefgh", 3, "abc\ndef\ngh"},
+		{"emoji", "👍 👍 👍", 5, "👍 👍\n👍"},
+		{"cjk", "日本語 テキスト", 6, "日本語\nテキス\nト"},
+		{"cjk never half a character", "日本語", 5, "日本\n語"},
+		{"zwj sequence", family + family, 3, family + "\n" + family},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.s, tt.width); got != tt.want {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"ascii", "ab", 4, "ab  "},
+		{"already wide enough", "abcdef", 3, "abcdef"},
+		{"cjk", "日本", 5, "日本 "},
+		{"emoji", "👍", 4, "👍  "},
+		{"zwj sequence", family, 3, family + " "},
+		{"combining mark", "e\u0301", 2, "e\u0301 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PadRight(tt.s, tt.width); got != tt.want {
+				t.Errorf("PadRight(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package topics remembers which matches of each saved search were already
+// shown, so running a topic again only shows what is new.
+package topics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/news"
+)
+
+// Marker is the last-seen marker of a topic
+type Marker struct {
+	// Seen maps the keys of the matches shown to when they were first
+	// shown. Articles are tracked by key rather than publication time, so
+	// items that show up late with an older date still count as new.
+	Seen map[string]time.Time `json:"seen,omitempty"`
+	// LastRun is when the topic was last shown
+	LastRun time.Time `json:"last_run"`
+}
+
+// statePath returns the location of the marker file
+func statePath() string {
+	return filepath.Join(config.Dir(), "topics_state.json")
+}
+
+// LoadMarkers reads the markers of every topic, by lower-cased name
+func LoadMarkers() map[string]Marker {
+	markers := make(map[string]Marker)
+	if data, err := os.ReadFile(statePath()); err == nil {
+		json.Unmarshal(data, &markers)
+	}
+	return markers
+}
+
+// SaveMarkers writes the markers of every topic
+func SaveMarkers(markers map[string]Marker) error {
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(markers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal topic markers: %w", err)
+	}
+	if err := os.WriteFile(statePath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to save topic markers: %w", err)
+	}
+	return nil
+}
+
+// Key returns the name a topic's marker is stored under
+func Key(name string) string {
+	return strings.ToLower(name)
+}
+
+// IsNew reports whether an article was not shown before
+func (m Marker) IsNew(article news.Article) bool {
+	_, seen := m.Seen[article.Key()]
+	return !seen
+}
+
+// New returns the articles not shown before
+func (m Marker) New(articles []news.Article) []news.Article {
+	var fresh []news.Article
+	for _, article := range articles {
+		if m.IsNew(article) {
+			fresh = append(fresh, article)
+		}
+	}
+	return fresh
+}
+
+// Advance marks the articles shown as seen. Keys of articles that no
+// longer match, such as those dropped from the cache, are forgotten so
+// the marker does not grow without bound.
+func (m Marker) Advance(shown, matches []news.Article) Marker {
+	now := time.Now()
+	seen := make(map[string]time.Time, len(matches))
+	for _, article := range matches {
+		if first, ok := m.Seen[article.Key()]; ok {
+			seen[article.Key()] = first
+		}
+	}
+	for _, article := range shown {
+		if _, ok := seen[article.Key()]; !ok {
+			seen[article.Key()] = now
+		}
+	}
+
+	m.Seen = seen
+	m.LastRun = now
+	return m
+}
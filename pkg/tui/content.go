@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"nwcli/pkg/news"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ContentFetcher downloads the full text of an article whose feed only
+// carries a teaser
+type ContentFetcher interface {
+	FetchFullContent(ctx context.Context, article news.Article) (news.Article, error)
+}
+
+// contentState tracks full text downloads. Its maps are shared between
+// copies of the model.
+type contentState struct {
+	fetcher ContentFetcher
+	loading map[string]bool
+	// failed articles are not fetched again automatically
+	failed map[string]bool
+}
+
+// fullContentMsg is sent when a full text download finishes
+type fullContentMsg struct {
+	key     string
+	article news.Article
+	err     error
+	forced  bool
+}
+
+// newContentState creates the download state, or nil without a fetcher
+func newContentState(fetcher ContentFetcher) *contentState {
+	if fetcher == nil {
+		return nil
+	}
+	return &contentState{
+		fetcher: fetcher,
+		loading: make(map[string]bool),
+		failed:  make(map[string]bool),
+	}
+}
+
+// loadFullContent starts downloading the selected article's full text.
+// Unless forced, articles that already have it or failed before are skipped.
+func (m *Model) loadFullContent(forced bool) tea.Cmd {
+	article, ok := m.selectedArticle()
+	if !ok {
+		return nil
+	}
+	if m.content == nil {
+		if forced {
+			m.statusMessage = "📄 Full text is not available here"
+		}
+		return nil
+	}
+
+	key := article.Key()
+	if m.content.loading[key] || article.Link == "" {
+		return nil
+	}
+	if !forced && (article.FullContent || m.content.failed[key]) {
+		return nil
+	}
+
+	m.content.loading[key] = true
+	fetcher := m.content.fetcher
+	return func() tea.Msg {
+		full, err := fetcher.FetchFullContent(context.Background(), article)
+		return fullContentMsg{key: key, article: full, err: err, forced: forced}
+	}
+}
+
+// updateFullContent replaces an article with its downloaded full text,
+// keeping the feed content when the download failed
+func (m Model) updateFullContent(msg fullContentMsg) (tea.Model, tea.Cmd) {
+	if m.content == nil {
+		return m, nil
+	}
+	delete(m.content.loading, msg.key)
+
+	if msg.err != nil {
+		m.content.failed[msg.key] = true
+		if msg.forced || m.isShowing(msg.key) {
+			m.statusMessage = fmt.Sprintf("⚠️  Full text unavailable, showing feed content: %v", msg.err)
+		}
+		return m, nil
+	}
+	delete(m.content.failed, msg.key)
+
+	m.replaceArticle(msg.article)
+	m.cache.forget(msg.key)
+	if m.isShowing(msg.key) {
+		// Reset the viewport key so the new content is rendered, keeping
+		// the reader's position
+		offset := m.viewport.offset
+		m.viewport.key = ""
+		m.updateViewport()
+		m.viewport.ScrollTo(offset)
+		if msg.forced {
+			m.statusMessage = "📄 Reloaded full text"
+		}
+	}
+
+	return m, nil
+}
+
+// replaceArticle swaps in a new copy of an article, and its search
+// snippet, in every tab
+func (m *Model) replaceArticle(article news.Article) {
+	replace := func(articles []news.Article, snippets map[string]news.Snippet, query string) {
+		for i := range articles {
+			if articles[i].Key() == article.Key() {
+				articles[i] = article
+				updateSnippet(snippets, article, query)
+			}
+		}
+	}
+
+	replace(m.articles, m.snippets, m.highlight)
+	if m.tabs != nil {
+		for _, view := range m.tabs.views {
+			replace(view.articles, view.snippets, view.highlight)
+		}
+	}
+}
+
+// isShowing reports whether the article view shows the article with key
+func (m Model) isShowing(key string) bool {
+	article, ok := m.selectedArticle()
+	return ok && m.currentView == ArticleView && article.Key() == key
+}
+
+// contentLoading reports whether the selected article's full text is
+// being downloaded
+func (m Model) contentLoading() bool {
+	article, ok := m.selectedArticle()
+	return ok && m.content != nil && m.content.loading[article.Key()]
+}
@@ -0,0 +1,453 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"nwcli/pkg/news"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// searchState holds a search query and whether its prompt is open
+type searchState struct {
+	query   string
+	editing bool
+}
+
+// filterMenu lets the user pick a source or category filter
+type filterMenu struct {
+	items  []filterMenuItem
+	cursor int
+}
+
+// filterMenuItem is a single entry in the filter menu
+type filterMenuItem struct {
+	label    string
+	source   string
+	category string
+	clear    bool
+}
+
+// selectedArticle returns the currently selected visible article
+func (m Model) selectedArticle() (news.Article, bool) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.visible) {
+		return news.Article{}, false
+	}
+	return m.articles[m.visible[m.selectedIndex]], true
+}
+
+// applyFilters recomputes the visible articles, keeping the current
+// selection when it is still visible
+func (m *Model) applyFilters() {
+	selectedKey := ""
+	if article, ok := m.selectedArticle(); ok {
+		selectedKey = article.Key()
+	}
+
+	visible := make([]int, 0, len(m.articles))
+	for i, article := range m.articles {
+		if m.passesFilters(article) {
+			visible = append(visible, i)
+		}
+	}
+	m.visible = visible
+
+	m.selectedIndex = 0
+	for i, idx := range m.visible {
+		if m.articles[idx].Key() == selectedKey {
+			m.selectedIndex = i
+			break
+		}
+	}
+	if len(m.visible) == 0 {
+		m.selectedIndex = -1
+	}
+}
+
+// passesFilters reports whether an article matches all active filters
+func (m Model) passesFilters(article news.Article) bool {
+	if m.sourceFilter != "" && !strings.EqualFold(article.Source, m.sourceFilter) {
+		return false
+	}
+
+	if m.categoryFilter != "" && !article.HasCategory(m.categoryFilter) {
+		return false
+	}
+
+	if m.onlyNew && !m.lastSession.IsZero() && !article.Published.After(m.lastSession) {
+		return false
+	}
+
+	return matchesQuery(article, m.search.query)
+}
+
+// hasFilters reports whether any filter narrows the index
+func (m Model) hasFilters() bool {
+	return m.search.query != "" || m.sourceFilter != "" || m.categoryFilter != "" || m.onlyNew
+}
+
+// filterSummary describes the active filters for the index header
+func (m Model) filterSummary() string {
+	var parts []string
+	if m.search.query != "" {
+		parts = append(parts, fmt.Sprintf("🔍 %q", m.search.query))
+	}
+	if m.sourceFilter != "" {
+		parts = append(parts, "📡 "+m.sourceFilter)
+	}
+	if m.categoryFilter != "" {
+		parts = append(parts, "🏷️ "+m.categoryFilter)
+	}
+	if m.onlyNew {
+		parts = append(parts, "🆕 since "+m.lastSession.Format("Jan 2 15:04"))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// matchesQuery reports whether every term of query occurs in the article
+func matchesQuery(article news.Article, query string) bool {
+	if strings.TrimSpace(query) == "" {
+		return true
+	}
+
+	haystack := strings.ToLower(strings.Join([]string{
+		article.Title, article.Description, article.Summary, article.Content, article.Source,
+	}, "\n"))
+
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// findMatches returns the byte ranges in text where any term of query
+// occurs, compared case-insensitively
+func findMatches(text, query string) [][2]int {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var matches [][2]int
+	for i := 0; i < len(text); {
+		matched := 0
+		for _, term := range terms {
+			if end := i + len(term); end <= len(text) && strings.EqualFold(text[i:end], term) {
+				if len(term) > matched {
+					matched = len(term)
+				}
+			}
+		}
+		if matched > 0 {
+			matches = append(matches, [2]int{i, i + matched})
+			i += matched
+			continue
+		}
+		// Advance to the next rune boundary
+		i++
+		for i < len(text) && text[i]&0xC0 == 0x80 {
+			i++
+		}
+	}
+
+	return matches
+}
+
+// highlightMatches renders text with query matches in the highlight style
+func highlightMatches(text, query string, base, highlight lipgloss.Style) string {
+	matches := findMatches(text, query)
+	if len(matches) == 0 {
+		return base.Render(text)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] > last {
+			b.WriteString(base.Render(text[last:match[0]]))
+		}
+		b.WriteString(highlight.Render(text[match[0]:match[1]]))
+		last = match[1]
+	}
+	if last < len(text) {
+		b.WriteString(base.Render(text[last:]))
+	}
+
+	return b.String()
+}
+
+// highlightLine re-renders a styled line with query matches highlighted.
+// Lines without matches are returned unchanged.
+func highlightLine(line, query string, highlight lipgloss.Style) string {
+	plain := ansi.Strip(line)
+	if len(findMatches(plain, query)) == 0 {
+		return line
+	}
+	return highlightMatches(plain, query, lipgloss.NewStyle(), highlight)
+}
+
+// setHighlight marks the matches of a search query and finds a snippet
+// around them for every article
+func (m *Model) setHighlight(query string) {
+	m.highlight = strings.TrimSpace(query)
+	m.snippets = nil
+	if m.highlight == "" {
+		return
+	}
+
+	m.snippets = make(map[string]news.Snippet)
+	for _, article := range m.articles {
+		updateSnippet(m.snippets, article, m.highlight)
+	}
+}
+
+// updateSnippet stores the snippet around the first match of query in
+// article, or removes the article's snippet when nothing matches
+func updateSnippet(snippets map[string]news.Snippet, article news.Article, query string) {
+	if snippets == nil || query == "" {
+		return
+	}
+
+	for _, match := range news.FindMatches(article, query) {
+		if len(match.Snippets) > 0 {
+			snippets[article.Key()] = match.Snippets[0]
+			return
+		}
+	}
+	delete(snippets, article.Key())
+}
+
+// matchQuery returns the query highlighted in the index: the index search,
+// else the query that found the articles
+func (m Model) matchQuery() string {
+	if m.search.query != "" {
+		return m.search.query
+	}
+	return m.highlight
+}
+
+// bodyQuery returns the query highlighted in the article view: the body
+// search, else the query that found the articles
+func (m Model) bodyQuery() string {
+	if m.bodySearch.query != "" {
+		return m.bodySearch.query
+	}
+	return m.highlight
+}
+
+// renderSnippet renders a search snippet with its matches highlighted
+func renderSnippet(snippet news.Snippet, base, highlight lipgloss.Style) string {
+	var b strings.Builder
+	for _, segment := range snippet.Segments() {
+		if segment.Match {
+			b.WriteString(highlight.Render(segment.Text))
+		} else {
+			b.WriteString(base.Render(segment.Text))
+		}
+	}
+	return b.String()
+}
+
+// jumpMatch moves the index selection to the next (dir > 0) or previous
+// article whose title matches the search, wrapping around
+func (m *Model) jumpMatch(dir int) {
+	count := len(m.visible)
+	if count == 0 || m.search.query == "" {
+		return
+	}
+
+	for step := 1; step <= count; step++ {
+		idx := ((m.selectedIndex+dir*step)%count + count) % count
+		if len(findMatches(m.articles[m.visible[idx]].Title, m.search.query)) > 0 {
+			m.selectedIndex = idx
+			return
+		}
+	}
+
+	// No title matches: just move through the filtered list
+	m.selectedIndex = ((m.selectedIndex+dir)%count + count) % count
+}
+
+// jumpBodyMatch scrolls the article view to the next (dir > 0) or previous
+// line that matches the body search
+func (m *Model) jumpBodyMatch(dir int) {
+	if m.bodySearch.query == "" {
+		return
+	}
+
+	article, ok := m.selectedArticle()
+	if !ok {
+		return
+	}
+
+	lines := m.viewport.lines
+	if m.viewport.key != article.Key() {
+		lines = strings.Split(m.articleContent(article), "\n")
+	}
+	count := len(lines)
+	if count == 0 {
+		return
+	}
+
+	for step := 1; step <= count; step++ {
+		idx := ((m.viewport.offset+dir*step)%count + count) % count
+		if len(findMatches(ansi.Strip(lines[idx]), m.bodySearch.query)) > 0 {
+			m.viewport.ScrollTo(idx)
+			m.statusMessage = ""
+			return
+		}
+	}
+
+	m.statusMessage = fmt.Sprintf("🔍 No matches for %q", m.bodySearch.query)
+}
+
+// updatePrompt handles key presses while a search prompt is open
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	state := &m.search
+	if m.currentView == ArticleView {
+		state = &m.bodySearch
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		state.query = ""
+		state.editing = false
+	case tea.KeyEnter:
+		state.editing = false
+		if m.currentView == ArticleView {
+			m.viewport.offset--
+			m.jumpBodyMatch(1)
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if runes := []rune(state.query); len(runes) > 0 {
+			state.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		state.query += " "
+	case tea.KeyRunes:
+		state.query += string(msg.Runes)
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+
+	if m.currentView == IndexView {
+		m.applyFilters()
+	}
+
+	return m, nil
+}
+
+// openFilterMenu builds the source/category menu from the loaded articles
+func (m *Model) openFilterMenu() {
+	sources := make(map[string]bool)
+	categories := make(map[string]bool)
+	for _, article := range m.articles {
+		sources[article.Source] = true
+		for _, cat := range article.Categories {
+			categories[cat] = true
+		}
+	}
+
+	menu := &filterMenu{
+		items: []filterMenuItem{{label: "✖ Clear source & category filters", clear: true}},
+	}
+	for _, source := range sortedKeys(sources) {
+		menu.items = append(menu.items, filterMenuItem{label: "📡 " + source, source: source})
+	}
+	for _, category := range sortedKeys(categories) {
+		menu.items = append(menu.items, filterMenuItem{label: "🏷️  " + category, category: category})
+	}
+
+	m.filterMenu = menu
+}
+
+// updateFilterMenu handles key presses while the filter menu is open
+func (m Model) updateFilterMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.filterMenu
+
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+
+	switch m.keymap.action(msg.String()) {
+	case actionBack, actionFilter, actionQuit:
+		m.filterMenu = nil
+	case actionUp:
+		if menu.cursor > 0 {
+			menu.cursor--
+		}
+	case actionDown:
+		if menu.cursor < len(menu.items)-1 {
+			menu.cursor++
+		}
+	case actionOpen:
+		item := menu.items[menu.cursor]
+		switch {
+		case item.clear:
+			m.sourceFilter = ""
+			m.categoryFilter = ""
+		case item.source != "":
+			m.sourceFilter = item.source
+		case item.category != "":
+			m.categoryFilter = item.category
+		}
+		m.filterMenu = nil
+		m.applyFilters()
+	}
+
+	return m, nil
+}
+
+// renderFilterMenu renders the filter menu
+func (m Model) renderFilterMenu() string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).MarginBottom(1)
+	b.WriteString(titleStyle.Render("Filter by source or category"))
+	b.WriteString("\n")
+
+	for i, item := range m.filterMenu.items {
+		line := "  " + item.label
+		style := lipgloss.NewStyle()
+		if i == m.filterMenu.cursor {
+			line = "▶ " + item.label
+			style = style.Bold(true).Foreground(m.theme.BorderSelected)
+		}
+		if (item.source != "" && item.source == m.sourceFilter) ||
+			(item.category != "" && item.category == m.categoryFilter) {
+			line += " ✓"
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	keys := m.keymap
+	b.WriteString(fmt.Sprintf("\n%s/%s choose • %s apply • %s close",
+		keys.hint(actionUp), keys.hint(actionDown), keys.hint(actionOpen), keys.hint(actionBack)))
+
+	boxStyle := lipgloss.NewStyle().
+		Padding(1, 2).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Accent)
+
+	return boxStyle.Render(b.String())
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,360 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"nwcli/pkg/textutil"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// action is something a key can be bound to
+type action string
+
+const (
+	actionQuit          action = "quit"
+	actionUp            action = "up"
+	actionDown          action = "down"
+	actionPageUp        action = "page_up"
+	actionPageDown      action = "page_down"
+	actionTop           action = "top"
+	actionBottom        action = "bottom"
+	actionOpen          action = "open"
+	actionBack          action = "back"
+	actionSearch        action = "search"
+	actionNextMatch     action = "next_match"
+	actionPrevMatch     action = "prev_match"
+	actionFilter        action = "filter"
+	actionOnlyNew       action = "only_new"
+	actionRefresh       action = "refresh"
+	actionPlay          action = "play"
+	actionOpenBrowser   action = "open_browser"
+	actionCopyLink      action = "copy_link"
+	actionCopyMarkdown  action = "copy_markdown"
+	actionSave          action = "save"
+	actionLinks         action = "links"
+	actionFetchFull     action = "fetch_full"
+	actionNextArticle   action = "next_article"
+	actionPrevArticle   action = "prev_article"
+	actionReaderMode    action = "reader_mode"
+	actionNextTab       action = "next_tab"
+	actionPrevTab       action = "prev_tab"
+	actionAddTab        action = "add_tab"
+	actionCloseTab      action = "close_tab"
+	actionMoveTabLeft   action = "move_tab_left"
+	actionMoveTabRight  action = "move_tab_right"
+	actionTogglePreview action = "toggle_preview"
+	actionGrowList      action = "grow_list"
+	actionShrinkList    action = "shrink_list"
+	actionResetSplit    action = "reset_split"
+	actionHelp          action = "help"
+)
+
+// actionInfo describes an action for the help overlay
+type actionInfo struct {
+	action      action
+	description string
+	index       bool // available in the index
+	article     bool // available in the article view
+}
+
+// actions lists every action in help order
+var actions = []actionInfo{
+	{actionUp, "move up / scroll up", true, true},
+	{actionDown, "move down / scroll down", true, true},
+	{actionPageUp, "page up", true, true},
+	{actionPageDown, "page down", true, true},
+	{actionTop, "first article / top", true, true},
+	{actionBottom, "last article / bottom", true, true},
+	{actionOpen, "read article", true, false},
+	{actionBack, "back / clear filters", true, true},
+	{actionSearch, "search", true, true},
+	{actionNextMatch, "next match", true, true},
+	{actionPrevMatch, "previous match", true, true},
+	{actionFilter, "filter by source or category", true, false},
+	{actionOnlyNew, "new since last session", true, false},
+	{actionRefresh, "refresh feeds", true, true},
+	{actionPlay, "play media", true, true},
+	{actionOpenBrowser, "open in browser", true, true},
+	{actionCopyLink, "copy link", true, true},
+	{actionCopyMarkdown, "copy markdown link", true, true},
+	{actionSave, "save as markdown", true, true},
+	{actionLinks, "list links", true, true},
+	{actionFetchFull, "load full text", true, true},
+	{actionNextArticle, "next article", false, true},
+	{actionPrevArticle, "previous article", false, true},
+	{actionReaderMode, "toggle reader mode", true, true},
+	{actionNextTab, "next tab", true, true},
+	{actionPrevTab, "previous tab", true, true},
+	{actionAddTab, "add tab", true, true},
+	{actionCloseTab, "close tab", true, true},
+	{actionMoveTabLeft, "move tab left", true, true},
+	{actionMoveTabRight, "move tab right", true, true},
+	{actionTogglePreview, "toggle preview pane", true, false},
+	{actionGrowList, "widen list", true, false},
+	{actionShrinkList, "narrow list", true, false},
+	{actionResetSplit, "reset pane sizes", true, false},
+	{actionHelp, "toggle help", true, true},
+	{actionQuit, "quit", true, true},
+}
+
+// tabActions only apply when the reader has tabs
+var tabActions = map[action]bool{
+	actionNextTab:      true,
+	actionPrevTab:      true,
+	actionAddTab:       true,
+	actionCloseTab:     true,
+	actionMoveTabLeft:  true,
+	actionMoveTabRight: true,
+}
+
+// defaultKeys are the bindings of the default preset
+var defaultKeys = map[action][]string{
+	actionQuit:          {"q", "ctrl+c"},
+	actionUp:            {"up", "k"},
+	actionDown:          {"down", "j"},
+	actionPageUp:        {"pgup"},
+	actionPageDown:      {"pgdown"},
+	actionTop:           {"home", "g"},
+	actionBottom:        {"end", "G"},
+	actionOpen:          {"enter"},
+	actionBack:          {"esc"},
+	actionSearch:        {"/"},
+	actionNextMatch:     {"n"},
+	actionPrevMatch:     {"N"},
+	actionFilter:        {"f"},
+	actionOnlyNew:       {"u"},
+	actionRefresh:       {"r"},
+	actionPlay:          {"P"},
+	actionOpenBrowser:   {"o"},
+	actionCopyLink:      {"y"},
+	actionCopyMarkdown:  {"Y"},
+	actionSave:          {"s"},
+	actionLinks:         {"L"},
+	actionFetchFull:     {"F"},
+	actionNextArticle:   {"]"},
+	actionPrevArticle:   {"[", "p"},
+	actionReaderMode:    {"R"},
+	actionNextTab:       {"tab"},
+	actionPrevTab:       {"shift+tab"},
+	actionAddTab:        {"t"},
+	actionCloseTab:      {"x"},
+	actionMoveTabLeft:   {"<"},
+	actionMoveTabRight:  {">"},
+	actionTogglePreview: {"v"},
+	actionGrowList:      {"+"},
+	actionShrinkList:    {"-"},
+	actionResetSplit:    {"="},
+	actionHelp:          {"h", "?"},
+}
+
+// keymapPresets change the default bindings for each preset
+var keymapPresets = map[string]map[action][]string{
+	"default": {},
+	"vim": {
+		actionPageUp:   {"pgup", "ctrl+b", "ctrl+u"},
+		actionPageDown: {"pgdown", "ctrl+f", "ctrl+d"},
+		actionOpen:     {"enter", "l"},
+		actionBack:     {"esc", "h"},
+		actionHelp:     {"?"},
+	},
+	"emacs": {
+		actionUp:       {"up", "ctrl+p"},
+		actionDown:     {"down", "ctrl+n"},
+		actionPageUp:   {"pgup", "alt+v"},
+		actionPageDown: {"pgdown", "ctrl+v"},
+		actionTop:      {"home", "alt+<"},
+		actionBottom:   {"end", "alt+>"},
+		actionBack:     {"esc", "ctrl+g"},
+		actionSearch:   {"/", "ctrl+s"},
+		actionRefresh:  {"r", "g"},
+		actionQuit:     {"q", "ctrl+c", "ctrl+x"},
+	},
+}
+
+// Keymap maps keys to actions
+type Keymap struct {
+	keys     map[action][]string
+	bindings map[string]action
+}
+
+// KeymapPresets returns the names of the keymap presets
+func KeymapPresets() []string {
+	names := make([]string, 0, len(keymapPresets))
+	for name := range keymapPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewKeymap builds a keymap from a preset and per-action overrides. An
+// override replaces all keys of its action and takes those keys away from
+// any other action.
+func NewKeymap(preset string, overrides map[string][]string) (Keymap, error) {
+	if preset == "" {
+		preset = "default"
+	}
+	changes, ok := keymapPresets[preset]
+	if !ok {
+		return Keymap{}, fmt.Errorf("unknown keymap %q (available: %s)", preset, strings.Join(KeymapPresets(), ", "))
+	}
+
+	km := Keymap{keys: make(map[action][]string)}
+	for act, keys := range defaultKeys {
+		km.keys[act] = keys
+	}
+	for act, keys := range changes {
+		km.bind(act, keys)
+	}
+
+	overridden := make(map[string]action)
+	for name, keys := range overrides {
+		act := action(name)
+		if _, ok := defaultKeys[act]; !ok {
+			return Keymap{}, fmt.Errorf("unknown key action %q", name)
+		}
+		for _, key := range keys {
+			if other, ok := overridden[key]; ok {
+				return Keymap{}, fmt.Errorf("key %q is bound to both %s and %s", key, other, act)
+			}
+			overridden[key] = act
+		}
+		km.bind(act, keys)
+	}
+
+	km.bindings = make(map[string]action)
+	for act, keys := range km.keys {
+		for _, key := range keys {
+			km.bindings[key] = act
+		}
+	}
+
+	return km, nil
+}
+
+// bind sets the keys of an action, removing them from other actions
+func (km *Keymap) bind(act action, keys []string) {
+	taken := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		taken[key] = true
+	}
+
+	for other, otherKeys := range km.keys {
+		if other == act {
+			continue
+		}
+		var kept []string
+		for _, key := range otherKeys {
+			if !taken[key] {
+				kept = append(kept, key)
+			}
+		}
+		km.keys[other] = kept
+	}
+
+	km.keys[act] = keys
+}
+
+// action returns the action bound to a key, or an empty action
+func (km Keymap) action(key string) action {
+	return km.bindings[key]
+}
+
+// hint returns the primary key of an action for footer hints
+func (km Keymap) hint(act action) string {
+	keys := km.keys[act]
+	if len(keys) == 0 {
+		return "-"
+	}
+	return keyLabel(keys[0])
+}
+
+// keyLabels prettifies key names for display
+var keyLabels = map[string]string{
+	"up":        "↑",
+	"down":      "↓",
+	"enter":     "⏎",
+	"esc":       "ESC",
+	"pgup":      "PgUp",
+	"pgdown":    "PgDn",
+	"home":      "Home",
+	"end":       "End",
+	"tab":       "Tab",
+	"shift+tab": "Shift+Tab",
+}
+
+// keyLabel returns the display label of a key
+func keyLabel(key string) string {
+	if label, ok := keyLabels[key]; ok {
+		return label
+	}
+	return key
+}
+
+// renderHelp renders the help overlay for the current view from the
+// active keymap
+func (m Model) renderHelp() string {
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Highlight)
+	descStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+
+	var rows [][2]string
+	width := 0
+	for _, info := range actions {
+		if m.currentView == IndexView && !info.index || m.currentView == ArticleView && !info.article {
+			continue
+		}
+		if m.tabs == nil && tabActions[info.action] {
+			continue
+		}
+		keys := m.keymap.keys[info.action]
+		if len(keys) == 0 {
+			continue
+		}
+
+		labels := make([]string, len(keys))
+		for i, key := range keys {
+			labels[i] = keyLabel(key)
+		}
+		label := strings.Join(labels, " / ")
+		if w := textutil.Width(label); w > width {
+			width = w
+		}
+		rows = append(rows, [2]string{label, info.description})
+	}
+	if m.tabs != nil {
+		rows = append(rows, [2]string{"1 … 9", "go to tab"})
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = keyStyle.Render(textutil.PadRight(row[0], width)) + "  " + descStyle.Render(row[1])
+	}
+
+	// Use two columns when one does not fit on the screen; the box, title
+	// and closing hint take eight rows
+	columns := strings.Join(lines, "\n")
+	if len(lines)+8 > m.windowHeight {
+		half := (len(lines) + 1) / 2
+		left := lipgloss.NewStyle().MarginRight(4).Render(strings.Join(lines[:half], "\n"))
+		columns = lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Join(lines[half:], "\n"))
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).MarginBottom(1).Render("⌨️  Keys"))
+	b.WriteString("\n")
+	b.WriteString(columns)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Muted).Render(
+		"Press any key to close"))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Accent).
+		Render(b.String())
+
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
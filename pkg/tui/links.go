@@ -0,0 +1,338 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/news"
+	"nwcli/pkg/renderer"
+	"nwcli/pkg/textutil"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	// markdownLinkPattern matches [text](url) links
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\((https?://[^)\s]+)\)`)
+	// hrefPattern matches links in HTML content
+	hrefPattern = regexp.MustCompile(`href=["'](https?://[^"']+)["']`)
+	// urlPattern matches bare URLs
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'()\[\]\x1b]+`)
+)
+
+// link is a URL found in an article
+type link struct {
+	label string
+	url   string
+}
+
+// linkMenu lists an article's links with numbered hints
+type linkMenu struct {
+	links  []link
+	cursor int
+	// number holds digits typed to pick a link by its hint
+	number string
+}
+
+// browserOpenedMsg is sent when the browser has been started
+type browserOpenedMsg struct {
+	url string
+	err error
+}
+
+// extractLinks returns the article's links in reading order without
+// duplicates: the article itself, comments, links in the content and
+// attachments
+func extractLinks(article news.Article) []link {
+	var links []link
+	seen := make(map[string]bool)
+	add := func(label, url string) {
+		url = strings.TrimRight(url, ".,;:!?")
+		if url == "" || seen[url] {
+			return
+		}
+		seen[url] = true
+		links = append(links, link{label: strings.TrimSpace(label), url: url})
+	}
+
+	add("Article", article.Link)
+	add("Comments", article.CommentsURL)
+
+	for _, text := range []string{article.Content, article.Description} {
+		for _, match := range markdownLinkPattern.FindAllStringSubmatch(text, -1) {
+			add(match[1], match[2])
+		}
+		for _, match := range hrefPattern.FindAllStringSubmatch(text, -1) {
+			add("", match[1])
+		}
+		for _, url := range urlPattern.FindAllString(text, -1) {
+			add("", url)
+		}
+	}
+
+	for _, enc := range article.Enclosures {
+		add("Attachment", enc.URL)
+	}
+	for _, media := range article.Media {
+		add(media.Type, media.URL)
+	}
+	add("Image", article.ImageURL)
+
+	return links
+}
+
+// browserCommand returns the command that opens url in the browser, from
+// $BROWSER or the platform default
+func browserCommand(url string) *exec.Cmd {
+	if fields := strings.Fields(os.Getenv("BROWSER")); len(fields) > 0 {
+		return exec.Command(fields[0], append(fields[1:], url)...)
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+// openURL opens url in the browser without blocking the TUI
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := browserCommand(url)
+		if err := cmd.Start(); err != nil {
+			return browserOpenedMsg{url: url, err: err}
+		}
+		go cmd.Wait()
+		return browserOpenedMsg{url: url}
+	}
+}
+
+// copyToClipboard copies text to the system clipboard with an OSC 52
+// escape sequence, which also works over SSH
+func copyToClipboard(text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
+
+// saveArticle writes the article as markdown to dir and returns the path
+func saveArticle(article news.Article, dir string) (string, error) {
+	if dir == "" {
+		dir = filepath.Join(config.Dir(), "saved")
+	}
+	if strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[2:])
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, article.Slug()+".md")
+	if err := os.WriteFile(path, []byte(renderer.ArticleMarkdown(article)), 0644); err != nil {
+		return "", fmt.Errorf("failed to save article: %w", err)
+	}
+
+	return path, nil
+}
+
+// hyperlinksSupported reports whether the terminal is likely to understand
+// OSC 8 hyperlinks. NWCLI_HYPERLINKS=0 or 1 overrides the guess.
+func hyperlinksSupported() bool {
+	if value := os.Getenv("NWCLI_HYPERLINKS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		return err == nil && enabled
+	}
+
+	term := os.Getenv("TERM")
+	if term == "" || term == "dumb" || term == "linux" {
+		return false
+	}
+	// Terminal.app prints the escape sequences literally
+	return os.Getenv("TERM_PROGRAM") != "Apple_Terminal"
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to url
+func hyperlink(text, url string) string {
+	return ansi.SetHyperlink(url) + text + ansi.ResetHyperlink()
+}
+
+// linkifyLines turns URLs in rendered text into OSC 8 hyperlinks. URLs
+// interrupted by styling or wrapping are left as they are.
+func linkifyLines(rendered string) string {
+	return urlPattern.ReplaceAllStringFunc(rendered, func(url string) string {
+		return hyperlink(url, url)
+	})
+}
+
+// openLinkMenu lists the selected article's links
+func (m *Model) openLinkMenu() {
+	article, ok := m.selectedArticle()
+	if !ok {
+		return
+	}
+
+	links := extractLinks(article)
+	if len(links) == 0 {
+		m.statusMessage = "🔗 This article has no links"
+		return
+	}
+
+	m.linkMenu = &linkMenu{links: links}
+}
+
+// updateLinkMenu handles key presses while the link list is open
+func (m Model) updateLinkMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.linkMenu
+
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+
+	// Digits pick a link by its hint
+	if key := msg.String(); len(key) == 1 && key >= "0" && key <= "9" {
+		menu.number += key
+		n, _ := strconv.Atoi(menu.number)
+		if n < 1 || n > len(menu.links) {
+			menu.number = ""
+			return m, nil
+		}
+		menu.cursor = n - 1
+		// Open straight away once typing more digits cannot match
+		if n*10 > len(menu.links) {
+			return m.openLink(menu.cursor)
+		}
+		return m, nil
+	}
+	if msg.Type == tea.KeyBackspace {
+		menu.number = ""
+		return m, nil
+	}
+
+	switch m.keymap.action(msg.String()) {
+	case actionBack, actionLinks, actionQuit:
+		m.linkMenu = nil
+	case actionUp:
+		if menu.cursor > 0 {
+			menu.cursor--
+		}
+		menu.number = ""
+	case actionDown:
+		if menu.cursor < len(menu.links)-1 {
+			menu.cursor++
+		}
+		menu.number = ""
+	case actionOpen, actionOpenBrowser:
+		return m.openLink(menu.cursor)
+	case actionCopyLink:
+		m.copyText(menu.links[menu.cursor].url, "🔗 Copied link")
+		m.linkMenu = nil
+	}
+
+	return m, nil
+}
+
+// openLink opens the i-th link of the menu and closes it
+func (m Model) openLink(i int) (tea.Model, tea.Cmd) {
+	url := m.linkMenu.links[i].url
+	m.linkMenu = nil
+	return m, openURL(url)
+}
+
+// copyText copies text to the clipboard and reports it in the status line
+func (m *Model) copyText(text, message string) {
+	if err := copyToClipboard(text); err != nil {
+		m.statusMessage = "⚠️  " + err.Error()
+		return
+	}
+	m.statusMessage = message
+}
+
+// renderLinkMenu renders the numbered link list over the screen
+func (m Model) renderLinkMenu() string {
+	menu := m.linkMenu
+
+	hintStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Highlight)
+	urlStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+
+	// Leave room for the box, title and key hints
+	rows := m.windowHeight - 9
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if menu.cursor >= rows {
+		start = menu.cursor - rows + 1
+	}
+	end := start + rows
+	if end > len(menu.links) {
+		end = len(menu.links)
+	}
+
+	width := m.windowWidth - 12
+	numberWidth := len(strconv.Itoa(len(menu.links)))
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).MarginBottom(1).Render(
+		fmt.Sprintf("🔗 %d links", len(menu.links))))
+	b.WriteString("\n")
+
+	for i := start; i < end; i++ {
+		l := menu.links[i]
+		marker := "  "
+		if i == menu.cursor {
+			marker = "▶ "
+		}
+
+		hint := fmt.Sprintf("[%*d]", numberWidth, i+1)
+		text := l.url
+		if l.label != "" {
+			text = l.label + " — " + l.url
+		}
+		text = textutil.Truncate(text, width-textutil.Width(marker+hint)-1)
+		if hyperlinksSupported() {
+			text = hyperlink(urlStyle.Render(text), l.url)
+		} else {
+			text = urlStyle.Render(text)
+		}
+
+		b.WriteString(marker + hintStyle.Render(hint) + " " + text + "\n")
+	}
+
+	keys := m.keymap
+	b.WriteString(fmt.Sprintf("\n1-9 pick • %s/%s choose • %s open • %s copy • %s close",
+		keys.hint(actionUp), keys.hint(actionDown), keys.hint(actionOpen), keys.hint(actionCopyLink), keys.hint(actionBack)))
+	if menu.number != "" {
+		b.WriteString(" • #" + menu.number)
+	}
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Accent).
+		Render(b.String())
+
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
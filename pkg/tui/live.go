@@ -0,0 +1,333 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// spinnerFrames are the frames of the fetch progress spinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Fetcher supplies fresh articles to the TUI in the background
+type Fetcher interface {
+	GetSources() []news.Source
+	FetchSource(source news.Source) ([]news.Article, error)
+}
+
+// FreshnessChecker is implemented by fetchers that know which sources were
+// fetched recently enough to skip when the reader opens
+type FreshnessChecker interface {
+	IsFresh(source news.Source) bool
+}
+
+// LiveOptions configures background fetching in the TUI
+type LiveOptions struct {
+	Fetcher Fetcher
+	// Filter selects which fetched articles belong in this view; nil keeps all
+	Filter func(news.Article) bool
+	// Limit caps the number of articles shown; zero means no limit
+	Limit int
+	// RefreshInterval triggers an automatic refresh; zero disables it
+	RefreshInterval time.Duration
+	// Tabs loads the articles of additional tabs; nil disables tabs
+	Tabs TabProvider
+	// Content downloads full article text when an article is opened; nil
+	// shows the feed content only
+	Content ContentFetcher
+	// Highlight is the query of search results: its matches are
+	// highlighted and the index shows a snippet around them
+	Highlight string
+}
+
+// fetchStatus tracks the progress of a single source during a refresh
+type fetchStatus struct {
+	done  bool
+	count int
+	err   error
+	// fresh sources were skipped because they are within their TTL
+	fresh bool
+}
+
+// liveState holds background refresh state
+type liveState struct {
+	options    LiveOptions
+	sources    []news.Source
+	status     map[string]*fetchStatus
+	fetching   bool
+	generation int
+	frame      int
+	newKeys    map[string]bool
+	newCount   int
+	lastFetch  time.Time
+}
+
+// sourceFetchedMsg is sent when one source finishes fetching
+type sourceFetchedMsg struct {
+	owner      *liveState
+	generation int
+	source     string
+	articles   []news.Article
+	err        error
+}
+
+// spinnerTickMsg advances the spinner while fetching
+type spinnerTickMsg struct {
+	owner *liveState
+}
+
+// autoRefreshMsg triggers a scheduled refresh
+type autoRefreshMsg struct {
+	owner      *liveState
+	generation int
+}
+
+// startRefresh begins fetching sources in the background: every source
+// when forced, otherwise only those not fresh in the cache
+func (m *Model) startRefresh(force bool) tea.Cmd {
+	if m.live == nil || m.live.fetching {
+		return nil
+	}
+
+	live := m.live
+	live.generation++
+	live.newCount = 0
+	live.status = make(map[string]*fetchStatus)
+
+	checker, _ := live.options.Fetcher.(FreshnessChecker)
+	var cmds []tea.Cmd
+	for _, source := range live.sources {
+		if !force && checker != nil && checker.IsFresh(source) {
+			live.status[source.Name] = &fetchStatus{done: true, fresh: true}
+			continue
+		}
+		live.status[source.Name] = &fetchStatus{}
+		cmds = append(cmds, fetchSourceCmd(live, source))
+	}
+
+	if len(cmds) == 0 {
+		return m.scheduleAutoRefresh()
+	}
+	live.fetching = true
+	return tea.Batch(append(cmds, spinnerTick(live))...)
+}
+
+// fetchSourceCmd fetches one source off the UI goroutine
+func fetchSourceCmd(live *liveState, source news.Source) tea.Cmd {
+	fetcher, generation := live.options.Fetcher, live.generation
+	return func() tea.Msg {
+		articles, err := fetcher.FetchSource(source)
+		return sourceFetchedMsg{
+			owner:      live,
+			generation: generation,
+			source:     source.Name,
+			articles:   articles,
+			err:        err,
+		}
+	}
+}
+
+// spinnerTick schedules the next spinner frame
+func spinnerTick(live *liveState) tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return spinnerTickMsg{owner: live}
+	})
+}
+
+// scheduleAutoRefresh schedules the next automatic refresh, if enabled
+func (m *Model) scheduleAutoRefresh() tea.Cmd {
+	if m.live == nil || m.live.options.RefreshInterval <= 0 {
+		return nil
+	}
+
+	live, generation := m.live, m.live.generation
+	return tea.Tick(live.options.RefreshInterval, func(time.Time) tea.Msg {
+		return autoRefreshMsg{owner: live, generation: generation}
+	})
+}
+
+// liveOwner returns the live state a background message belongs to
+func liveOwner(msg tea.Msg) *liveState {
+	switch msg := msg.(type) {
+	case sourceFetchedMsg:
+		return msg.owner
+	case spinnerTickMsg:
+		return msg.owner
+	case autoRefreshMsg:
+		return msg.owner
+	}
+	return nil
+}
+
+// updateLive handles background refresh messages, routing them to the
+// tab that started the refresh
+func (m Model) updateLive(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if owner := liveOwner(msg); owner != m.live {
+		return m.updateBackgroundTab(owner, msg)
+	}
+	if m.live == nil {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case spinnerTickMsg:
+		if !m.live.fetching {
+			return m, nil
+		}
+		m.live.frame = (m.live.frame + 1) % len(spinnerFrames)
+		return m, spinnerTick(m.live)
+
+	case autoRefreshMsg:
+		// Ignore ticks scheduled before a manual refresh
+		if msg.generation != m.live.generation {
+			return m, nil
+		}
+		return m, m.startRefresh(true)
+
+	case sourceFetchedMsg:
+		if msg.generation != m.live.generation {
+			return m, nil
+		}
+
+		status, ok := m.live.status[msg.source]
+		if !ok {
+			return m, nil
+		}
+		status.done = true
+		status.err = msg.err
+		status.count = len(msg.articles)
+
+		if msg.err == nil {
+			m.live.newCount += m.mergeArticles(msg.articles)
+		}
+
+		for _, s := range m.live.status {
+			if !s.done {
+				return m, nil
+			}
+		}
+
+		m.live.fetching = false
+		m.live.lastFetch = time.Now()
+		return m, m.scheduleAutoRefresh()
+	}
+
+	return m, nil
+}
+
+// mergeArticles adds fetched articles that are not shown yet and returns
+// how many were new
+func (m *Model) mergeArticles(fetched []news.Article) int {
+	existing := make(map[string]bool, len(m.articles))
+	for _, article := range m.articles {
+		existing[article.Key()] = true
+	}
+
+	added := 0
+	for _, article := range fetched {
+		if existing[article.Key()] {
+			continue
+		}
+		if m.live.options.Filter != nil && !m.live.options.Filter(article) {
+			continue
+		}
+		existing[article.Key()] = true
+		m.articles = append(m.articles, article)
+		updateSnippet(m.snippets, article, m.highlight)
+		m.live.newKeys[article.Key()] = true
+		added++
+	}
+
+	if added == 0 {
+		return 0
+	}
+
+	sort.SliceStable(m.articles, func(i, j int) bool {
+		return m.articles[i].Published.After(m.articles[j].Published)
+	})
+
+	if limit := m.live.options.Limit; limit > 0 && len(m.articles) > limit {
+		m.articles = m.articles[:limit]
+	}
+
+	m.applyFilters()
+	return added
+}
+
+// isNew reports whether an article arrived during this session
+func (m Model) isNew(article news.Article) bool {
+	return m.live != nil && m.live.newKeys[article.Key()]
+}
+
+// liveStatus describes the refresh state for the index header
+func (m Model) liveStatus() string {
+	if m.live == nil {
+		return ""
+	}
+	live := m.live
+
+	if live.fetching {
+		done, failed := 0, 0
+		var pending []string
+		for _, source := range live.sources {
+			status := live.status[source.Name]
+			switch {
+			case !status.done:
+				pending = append(pending, source.Name)
+			case status.err != nil:
+				done++
+				failed++
+			default:
+				done++
+			}
+		}
+
+		line := fmt.Sprintf("%s Fetching %d/%d sources", spinnerFrames[live.frame], done, len(live.sources))
+		if len(pending) > 0 {
+			if len(pending) > 3 {
+				pending = append(pending[:3], "…")
+			}
+			line += " • waiting for " + strings.Join(pending, ", ")
+		}
+		if failed > 0 {
+			line += fmt.Sprintf(" • ⚠️ %d failed", failed)
+		}
+		return line
+	}
+
+	var parts []string
+	if live.newCount > 0 {
+		parts = append(parts, fmt.Sprintf("✨ %d new", live.newCount))
+	}
+	var failed []string
+	fresh := 0
+	for _, source := range live.sources {
+		status := live.status[source.Name]
+		switch {
+		case status == nil:
+		case status.err != nil:
+			failed = append(failed, source.Name)
+		case status.fresh:
+			fresh++
+		}
+	}
+	if len(failed) > 0 {
+		parts = append(parts, "⚠️ failed: "+strings.Join(failed, ", "))
+	}
+	if fresh > 0 {
+		parts = append(parts, fmt.Sprintf("%d fresh in cache", fresh))
+	}
+	if !live.lastFetch.IsZero() {
+		parts = append(parts, "updated "+live.lastFetch.Format("15:04"))
+	}
+	if live.options.RefreshInterval > 0 {
+		parts = append(parts, "auto-refresh every "+live.options.RefreshInterval.String())
+	}
+
+	return strings.Join(parts, " • ")
+}
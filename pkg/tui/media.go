@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"nwcli/pkg/news"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// playerFinishedMsg is sent when the external media player exits
+type playerFinishedMsg struct {
+	err error
+}
+
+// playerCommand returns the media player command from $PLAYER (default mpv)
+func playerCommand() []string {
+	if fields := strings.Fields(os.Getenv("PLAYER")); len(fields) > 0 {
+		return fields
+	}
+	return []string{"mpv"}
+}
+
+// playMedia hands the article's first media item to the external player,
+// suspending the TUI while it runs
+func playMedia(article news.Article) (tea.Cmd, error) {
+	if len(article.Media) == 0 {
+		return nil, fmt.Errorf("this article has no audio or video")
+	}
+
+	player := playerCommand()
+	if _, err := exec.LookPath(player[0]); err != nil {
+		return nil, fmt.Errorf("player %q not found (set $PLAYER)", player[0])
+	}
+
+	args := append(player[1:], article.Media[0].URL)
+	cmd := exec.Command(player[0], args...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return playerFinishedMsg{err: err}
+	}), nil
+}
+
+// mediaBadge returns a short label for the article's media, if any
+func mediaBadge(article news.Article) string {
+	if len(article.Media) == 0 {
+		return ""
+	}
+
+	media := article.Media[0]
+	badge := "🎧 audio"
+	if media.Type == news.MediaVideo {
+		badge = "🎬 video"
+	}
+	if duration := media.DurationString(); duration != "" {
+		badge += " " + duration
+	}
+	return badge
+}
@@ -5,10 +5,12 @@ import (
 	"strings"
 	"time"
 
+	"nwcli/pkg/config"
 	"nwcli/pkg/news"
+	"nwcli/pkg/state"
+	"nwcli/pkg/textutil"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -18,11 +20,58 @@ type Model struct {
 	currentView   ViewType
 	selectedIndex int
 	title         string
-	renderer      *glamour.TermRenderer
 	viewport      Viewport
 	showHelp      bool
+	keymap        Keymap
+	theme         Theme
+	saveDir       string
 	windowWidth   int
 	windowHeight  int
+	statusMessage string
+
+	// Filtering: visible holds indices into articles that pass the
+	// active filters; selectedIndex indexes into visible
+	visible        []int
+	search         searchState
+	bodySearch     searchState
+	filterMenu     *filterMenu
+	linkMenu       *linkMenu
+	sourceFilter   string
+	categoryFilter string
+	onlyNew        bool
+	lastSession    time.Time
+
+	// highlight is the query that found the articles; snippets holds the
+	// context of its first match outside the title, by article key
+	highlight string
+	snippets  map[string]news.Snippet
+
+	// live is set when articles are refreshed in the background
+	live *liveState
+
+	// tabs is set when the reader shows multiple feed views
+	tabs *tabsState
+
+	// content is set when full article text can be downloaded
+	content *contentState
+
+	// Reader mode centers the article in a narrower column
+	readerMode  bool
+	readerWidth int
+	// positions holds reading positions by article key; it is shared
+	// between copies of the model
+	positions map[string]state.ReadPosition
+
+	// Split layout: the index shares the screen with a preview of the
+	// selected article when the window is at least splitMinWidth wide
+	splitEnabled  bool
+	splitRatio    float64
+	splitMinWidth int
+
+	// cache and layout are shared between copies of the model: View fills
+	// them and Update reads them
+	cache  *renderCache
+	layout *indexLayout
 }
 
 // ViewType represents the current view
@@ -33,21 +82,42 @@ const (
 	ArticleView
 )
 
-// Viewport represents a scrollable content area
-type Viewport struct {
-	content      string
-	scrollOffset int
-	height       int
-}
-
 // NewModel creates a new TUI model
 func NewModel(articles []news.Article, title string) (*Model, error) {
-	renderer, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(100),
-	)
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	session := state.Load()
+	splitRatio := session.SplitRatio
+	if splitRatio <= 0 {
+		splitRatio = defaultSplitRatio
+	}
+	splitMinWidth := cfg.TUI.SplitMinWidth
+	if splitMinWidth <= 0 {
+		splitMinWidth = defaultSplitMinWidth
+	}
+	readerWidth := cfg.TUI.ReaderWidth
+	if readerWidth <= 0 {
+		readerWidth = defaultReaderWidth
+	}
+	if readerWidth < minReaderWidth {
+		readerWidth = minReaderWidth
+	}
+	positions := session.Positions
+	if positions == nil {
+		positions = make(map[string]state.ReadPosition)
+	}
+
+	keymap, err := NewKeymap(cfg.TUI.Keymap, cfg.TUI.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	theme, err := LoadTheme(cfg.TUI.Theme)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create renderer: %w", err)
+		return nil, err
 	}
 
 	// Ensure we have at least one article to select
@@ -56,20 +126,67 @@ func NewModel(articles []news.Article, title string) (*Model, error) {
 		selectedIndex = -1 // No articles to select
 	}
 
-	return &Model{
+	model := &Model{
 		articles:      articles,
 		currentView:   IndexView,
 		selectedIndex: selectedIndex,
 		title:         title,
-		renderer:      renderer,
 		showHelp:      false,
+		keymap:        keymap,
+		theme:         theme,
+		saveDir:       cfg.TUI.SaveDir,
 		windowWidth:   80,
 		windowHeight:  24,
-	}, nil
+		lastSession:   session.LastSession,
+		splitEnabled:  true,
+		splitRatio:    splitRatio,
+		splitMinWidth: splitMinWidth,
+		readerMode:    cfg.TUI.ReaderMode,
+		readerWidth:   readerWidth,
+		positions:     positions,
+		cache:         newRenderCache(theme.glamourOption(), hyperlinksSupported()),
+		layout:        &indexLayout{},
+	}
+	model.applyFilters()
+
+	return model, nil
+}
+
+// NewLiveModel creates a TUI model that refreshes its articles in the
+// background, starting from the given (typically cached) articles
+func NewLiveModel(articles []news.Article, title string, opts LiveOptions) (*Model, error) {
+	model, err := NewModel(articles, title)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Fetcher != nil {
+		model.live = &liveState{
+			options: opts,
+			sources: opts.Fetcher.GetSources(),
+			newKeys: make(map[string]bool),
+		}
+	}
+
+	model.content = newContentState(opts.Content)
+	model.setHighlight(opts.Highlight)
+
+	if opts.Tabs != nil {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+		model.enableTabs(opts.Tabs, cfg.TUI.Tabs)
+	}
+
+	return model, nil
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
+	if m.live != nil {
+		return m.startRefresh(false)
+	}
 	return nil
 }
 
@@ -79,7 +196,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
 		m.windowHeight = msg.Height
-		m.viewport.height = msg.Height - 4 // Leave space for header/footer
+		m.updateViewport()
 
 	case tea.MouseMsg:
 		switch msg.Action {
@@ -94,90 +211,285 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			} else if msg.Button == tea.MouseButtonWheelDown {
 				if m.currentView == IndexView {
-					if m.selectedIndex < len(m.articles)-1 {
+					if m.selectedIndex < len(m.visible)-1 {
 						m.selectedIndex++
 					}
 				} else {
 					m.scrollDown()
 				}
 			} else if msg.Button == tea.MouseButtonLeft {
-				if m.currentView == IndexView && len(m.articles) > 0 {
-					// Calculate which article was clicked based on mouse position
-					// Each article takes about 6 lines with new styling
-					headerHeight := 6 // Approximate header height
-					if msg.Y >= headerHeight {
-						clickedIndex := (msg.Y - headerHeight) / 6
-						if clickedIndex >= 0 && clickedIndex < len(m.articles) {
-							m.selectedIndex = clickedIndex
-							m.currentView = ArticleView
-							m.updateViewport()
+				if listWidth, _ := m.paneWidths(); m.isSplit() && msg.X >= listWidth {
+					// Clicking the preview opens the article
+					return m, m.openArticle()
+				} else if m.currentView == IndexView {
+					// Map the click to the card drawn at that row in the
+					// last frame. In the split layout a click only selects,
+					// since the preview already shows the article.
+					if clicked, ok := m.layout.cardAt(msg.Y); ok {
+						m.selectedIndex = clicked
+						if !m.isSplit() {
+							return m, m.openArticle()
 						}
 					}
 				}
 			}
 		}
 
+	case sourceFetchedMsg, spinnerTickMsg, autoRefreshMsg:
+		return m.updateLive(msg)
+
+	case browserOpenedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("⚠️  Could not open browser: %v", msg.err)
+		} else {
+			m.statusMessage = "🌐 Opened " + msg.url
+		}
+
+	case fullContentMsg:
+		return m.updateFullContent(msg)
+
+	case playerFinishedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("⚠️  Player failed: %v", msg.err)
+		} else {
+			m.statusMessage = ""
+		}
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		if m.search.editing || m.bodySearch.editing {
+			return m.updatePrompt(msg)
+		}
+		if m.filterMenu != nil {
+			return m.updateFilterMenu(msg)
+		}
+		if m.linkMenu != nil {
+			return m.updateLinkMenu(msg)
+		}
+		if m.tabs != nil && m.tabs.prompt.editing {
+			return m.updateTabPrompt(msg)
+		}
+
+		if m.showHelp {
+			// Any key closes the help overlay; quit still quits
+			m.showHelp = false
+			if m.keymap.action(msg.String()) == actionQuit {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch m.keymap.action(msg.String()) {
+		case actionQuit:
+			m.rememberPosition()
 			return m, tea.Quit
 
-		case "h", "?":
-			m.showHelp = !m.showHelp
+		case actionSearch:
+			if m.currentView == IndexView {
+				m.search.editing = true
+			} else {
+				m.bodySearch = searchState{editing: true}
+			}
+
+		case actionNextMatch:
+			if m.currentView == IndexView {
+				m.jumpMatch(1)
+			} else if m.bodySearch.query == "" {
+				// Without a search, next moves on to the next article
+				return m, m.moveArticle(1)
+			} else {
+				m.jumpBodyMatch(1)
+			}
+
+		case actionPrevMatch:
+			if m.currentView == IndexView {
+				m.jumpMatch(-1)
+			} else {
+				m.jumpBodyMatch(-1)
+			}
+
+		case actionFilter:
+			if m.currentView == IndexView {
+				m.openFilterMenu()
+			}
+
+		case actionRefresh:
+			if m.live != nil {
+				return m, m.startRefresh(true)
+			}
+
+		case actionOnlyNew:
+			if m.currentView == IndexView {
+				if m.lastSession.IsZero() {
+					m.statusMessage = "🆕 No previous session recorded yet"
+				} else {
+					m.onlyNew = !m.onlyNew
+					m.statusMessage = ""
+					m.applyFilters()
+				}
+			}
+
+		case actionPlay:
+			if article, ok := m.selectedArticle(); ok {
+				cmd, err := playMedia(article)
+				if err != nil {
+					m.statusMessage = "⚠️  " + err.Error()
+					return m, nil
+				}
+				m.statusMessage = "▶️  Playing with " + playerCommand()[0]
+				return m, cmd
+			}
+
+		case actionOpenBrowser:
+			if article, ok := m.selectedArticle(); ok && article.Link != "" {
+				return m, openURL(article.Link)
+			}
+
+		case actionCopyLink:
+			if article, ok := m.selectedArticle(); ok {
+				m.copyText(article.Link, "🔗 Copied link")
+			}
+
+		case actionCopyMarkdown:
+			if article, ok := m.selectedArticle(); ok {
+				m.copyText(fmt.Sprintf("[%s](%s)", article.Title, article.Link), "🔗 Copied markdown link")
+			}
 
-		case "esc":
+		case actionSave:
+			if article, ok := m.selectedArticle(); ok {
+				path, err := saveArticle(article, m.saveDir)
+				if err != nil {
+					m.statusMessage = "⚠️  " + err.Error()
+				} else {
+					m.statusMessage = "💾 Saved to " + path
+				}
+			}
+
+		case actionLinks:
+			m.openLinkMenu()
+
+		case actionFetchFull:
+			return m, m.loadFullContent(true)
+
+		case actionNextArticle:
 			if m.currentView == ArticleView {
-				m.currentView = IndexView
-				m.updateViewport()
+				return m, m.moveArticle(1)
 			}
 
-		case "enter":
-			if m.currentView == IndexView && len(m.articles) > 0 && m.selectedIndex >= 0 {
-				m.currentView = ArticleView
+		case actionPrevArticle:
+			if m.currentView == ArticleView {
+				return m, m.moveArticle(-1)
+			}
+
+		case actionReaderMode:
+			if m.currentView == ArticleView {
+				m.rememberPosition()
+				m.readerMode = !m.readerMode
 				m.updateViewport()
+				m.restorePosition()
+			} else {
+				m.readerMode = !m.readerMode
+			}
+
+		case actionNextTab:
+			if m.tabs != nil {
+				return m, m.switchTab((m.tabs.active + 1) % len(m.tabs.views))
+			}
+
+		case actionPrevTab:
+			if m.tabs != nil {
+				count := len(m.tabs.views)
+				return m, m.switchTab((m.tabs.active - 1 + count) % count)
 			}
 
-		case "up", "k":
+		case actionAddTab:
+			if m.tabs != nil {
+				m.tabs.prompt = searchState{editing: true}
+			}
+
+		case actionCloseTab:
+			return m, m.closeTab()
+
+		case actionMoveTabLeft:
+			m.moveTab(-1)
+
+		case actionMoveTabRight:
+			m.moveTab(1)
+
+		case actionTogglePreview:
+			m.splitEnabled = !m.splitEnabled
+
+		case actionGrowList:
+			if m.isSplit() {
+				m.resizeSplit(splitStep)
+			}
+
+		case actionShrinkList:
+			if m.isSplit() {
+				m.resizeSplit(-splitStep)
+			}
+
+		case actionResetSplit:
+			m.splitRatio = defaultSplitRatio
+
+		case actionHelp:
+			m.showHelp = true
+
+		case actionBack:
+			if m.currentView == ArticleView {
+				m.rememberPosition()
+				m.currentView = IndexView
+				m.bodySearch = searchState{}
+			} else if m.hasFilters() {
+				m.search = searchState{}
+				m.sourceFilter = ""
+				m.categoryFilter = ""
+				m.onlyNew = false
+				m.applyFilters()
+			}
+
+		case actionOpen:
 			if m.currentView == IndexView {
-				if m.selectedIndex > 0 {
-					m.selectedIndex--
-				}
+				return m, m.openArticle()
+			}
+
+		case actionUp:
+			if m.currentView == IndexView {
+				m.moveSelection(-1)
 			} else {
 				m.scrollUp()
 			}
 
-		case "down", "j":
+		case actionDown:
 			if m.currentView == IndexView {
-				if m.selectedIndex < len(m.articles)-1 {
-					m.selectedIndex++
-				}
+				m.moveSelection(1)
 			} else {
 				m.scrollDown()
 			}
 
-		case "pgup":
+		case actionPageUp:
 			m.pageUp()
 
-		case "pgdown":
+		case actionPageDown:
 			m.pageDown()
 
-		case "home", "g":
+		case actionTop:
 			if m.currentView == IndexView {
 				m.selectedIndex = 0
 			} else {
-				m.viewport.scrollOffset = 0
+				m.viewport.ScrollTo(0)
 			}
 
-		case "end", "G":
+		case actionBottom:
 			if m.currentView == IndexView {
-				m.selectedIndex = len(m.articles) - 1
+				m.selectedIndex = len(m.visible) - 1
 			} else {
-				// Scroll to bottom
-				lines := strings.Count(m.viewport.content, "\n")
-				maxScroll := lines - m.viewport.height + 1
-				if maxScroll > 0 {
-					m.viewport.scrollOffset = maxScroll
-				}
+				m.viewport.GotoBottom()
+			}
+
+		default:
+			// Number keys jump straight to a tab
+			if key := msg.String(); m.tabs != nil && len(key) == 1 && key >= "1" && key <= "9" {
+				return m, m.switchTab(int(key[0] - '1'))
 			}
 		}
 	}
@@ -187,12 +499,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the current view
 func (m Model) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+	if m.linkMenu != nil {
+		return m.renderLinkMenu()
+	}
+
 	if len(m.articles) == 0 {
+		if m.live != nil && m.live.fetching {
+			return m.renderLoading()
+		}
 		return m.renderEmpty()
 	}
 
 	switch m.currentView {
 	case IndexView:
+		if m.isSplit() {
+			return m.renderSplit()
+		}
 		return m.renderIndex()
 	case ArticleView:
 		return m.renderArticle()
@@ -203,13 +528,25 @@ func (m Model) View() string {
 
 // renderIndex renders the article index
 func (m Model) renderIndex() string {
+	tabBar := m.renderTabBar()
+	barHeight := strings.Count(tabBar, "\n")
+
+	body := m
+	body.windowHeight -= barHeight
+	return tabBar + body.renderIndexBody(barHeight)
+}
+
+// renderIndexBody renders the index below the tab bar, which takes the
+// first top rows of the screen. Only the cards that fit in the window are
+// drawn, scrolled so the selection stays visible.
+func (m Model) renderIndexBody(top int) string {
 	var b strings.Builder
 
 	// Header with enhanced styling
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#5F87D7")).
+		Foreground(m.theme.HeaderFg).
+		Background(m.theme.HeaderBg).
 		Padding(1, 2).
 		BorderStyle(lipgloss.RoundedBorder()).
 		Width(m.windowWidth - 4).
@@ -219,36 +556,134 @@ func (m Model) renderIndex() string {
 	subHeader := fmt.Sprintf("%s • %d articles available",
 		time.Now().Format("Monday, January 2, 2006 at 15:04"),
 		len(m.articles))
+	if m.hasFilters() {
+		subHeader = fmt.Sprintf("%d of %d articles • %s",
+			len(m.visible), len(m.articles), m.filterSummary())
+	}
 
 	b.WriteString(headerStyle.Render(header))
 	b.WriteString("\n")
-	
+
 	// Sub-header
 	subHeaderStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8888AA")).
+		Foreground(m.theme.SubHeader).
 		Align(lipgloss.Center).
 		Width(m.windowWidth).
 		MarginBottom(1)
-	
+
 	b.WriteString(subHeaderStyle.Render(subHeader))
 	b.WriteString("\n\n")
 
+	if status := m.liveStatus(); status != "" {
+		liveStyle := lipgloss.NewStyle().
+			Foreground(m.theme.Accent).
+			Align(lipgloss.Center).
+			Width(m.windowWidth)
+		b.WriteString(liveStyle.Render(status))
+		b.WriteString("\n\n")
+	}
+
+	// Search prompt
+	if m.search.editing {
+		promptStyle := lipgloss.NewStyle().
+			Foreground(m.theme.Highlight).
+			Bold(true)
+		b.WriteString(promptStyle.Render("🔍 /" + m.search.query + "▏"))
+		b.WriteString("\n\n")
+	}
+
+	m.layout.cards = nil
+
+	if m.filterMenu != nil {
+		b.WriteString(m.renderFilterMenu())
+		return b.String()
+	}
+
 	// Check if we have articles to display
-	if len(m.articles) == 0 {
+	if len(m.visible) == 0 {
 		emptyStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888888")).
+			Foreground(m.theme.Muted).
 			Align(lipgloss.Center).
 			Width(m.windowWidth).
 			Padding(2)
-		
-		b.WriteString(emptyStyle.Render("📭 No articles found\n\nPress 'q' to quit"))
+
+		message := fmt.Sprintf("📭 No articles found\n\nPress %s to quit", m.keymap.hint(actionQuit))
+		if m.hasFilters() {
+			message = fmt.Sprintf("🔍 No articles match the current filters\n\nPress %s to clear them", m.keymap.hint(actionBack))
+		}
+		b.WriteString(emptyStyle.Render(message))
 		return b.String()
 	}
 
-	// Article list with enhanced styling
-	for i, article := range m.articles {
-		isSelected := i == m.selectedIndex
-		
+	footer := m.renderIndexFooter()
+
+	// Draw as many cards as fit between the header and the footer
+	row := strings.Count(b.String(), "\n")
+	available := m.windowHeight - row - lipgloss.Height(footer)
+	offset := m.scrollIndex(available)
+
+	row += top
+	bottom := row + available
+	for i := offset; i < len(m.visible); i++ {
+		card := m.renderCard(i)
+		height := lipgloss.Height(card)
+		if row+height > bottom && i > offset {
+			break
+		}
+
+		// The last line of a card is its bottom margin
+		m.layout.cards = append(m.layout.cards, cardBounds{top: row, bottom: row + height - 1, index: i})
+		b.WriteString(card)
+		b.WriteString("\n")
+		row += height
+	}
+
+	b.WriteString(footer)
+
+	return b.String()
+}
+
+// scrollIndex returns the first card to draw so that the selection is
+// fully visible within height rows, scrolling as little as possible
+// from the previous frame
+func (m Model) scrollIndex(height int) int {
+	offset := m.layout.offset
+	if offset >= len(m.visible) {
+		offset = len(m.visible) - 1
+	}
+	if m.selectedIndex < offset {
+		offset = m.selectedIndex
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	for offset < m.selectedIndex {
+		used := 0
+		for i := offset; i <= m.selectedIndex; i++ {
+			used += lipgloss.Height(m.renderCard(i))
+		}
+		if used <= height {
+			break
+		}
+		offset++
+	}
+
+	m.layout.offset = offset
+	return offset
+}
+
+// renderCard renders the index card of the i-th visible article
+func (m Model) renderCard(i int) string {
+	article := m.articles[m.visible[i]]
+	isSelected := i == m.selectedIndex
+	isNew := m.isNew(article)
+	timeAgo := formatTimeAgo(article.Published)
+	query := m.matchQuery()
+	snippet, hasSnippet := m.snippets[article.Key()]
+
+	key := fmt.Sprintf("%s|%d|%t|%t|%s|%t|%s|%s", article.Key(), m.windowWidth, isSelected, isNew, query, hasSnippet, timeAgo, article.Summary)
+	return m.cache.card(key, func() string {
 		// Base article container
 		containerStyle := lipgloss.NewStyle().
 			Width(m.windowWidth - 4).
@@ -258,111 +693,177 @@ func (m Model) renderIndex() string {
 
 		if isSelected {
 			containerStyle = containerStyle.
-				BorderForeground(lipgloss.Color("#FF6B9D")).
-				Background(lipgloss.Color("#2D1B4E")).
+				BorderForeground(m.theme.BorderSelected).
+				Background(m.theme.BackgroundSelected).
 				Bold(true)
 		} else {
 			containerStyle = containerStyle.
-				BorderForeground(lipgloss.Color("#3C3C3C")).
-				Background(lipgloss.Color("#1A1A1A"))
+				BorderForeground(m.theme.Border).
+				Background(m.theme.Background)
 		}
 
 		// Title styling
 		titleStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
+			Foreground(m.theme.Title).
 			Bold(true)
-		
+
 		if isSelected {
-			titleStyle = titleStyle.Foreground(lipgloss.Color("#FFB6D9"))
+			titleStyle = titleStyle.Foreground(m.theme.Highlight)
 		}
 
 		// Source and time styling
 		metaStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888888")).
+			Foreground(m.theme.Muted).
 			Italic(true).
 			MarginTop(1)
-		
+
 		if isSelected {
-			metaStyle = metaStyle.Foreground(lipgloss.Color("#BBBBBB"))
+			metaStyle = metaStyle.Foreground(m.theme.MutedSelected)
 		}
 
-		// Description styling  
+		// Description styling
 		descStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#CCCCCC")).
+			Foreground(m.theme.Text).
 			MarginTop(1)
-		
+
 		if isSelected {
-			descStyle = descStyle.Foreground(lipgloss.Color("#EEEEEE"))
+			descStyle = descStyle.Foreground(m.theme.TextSelected)
 		}
 
 		// Build article content
 		var articleContent strings.Builder
-		
+
 		// Selection indicator
 		indicator := "  "
 		if isSelected {
 			indicator = "▶ "
 		}
-		
-		articleContent.WriteString(indicator + titleStyle.Render(article.Title))
-		
+
+		highlightStyle := titleStyle.
+			Foreground(m.theme.MatchFg).
+			Background(m.theme.MatchBg)
+		articleContent.WriteString(indicator + highlightMatches(article.Title, query, titleStyle, highlightStyle))
+
 		// Source and time
-		sourceTime := fmt.Sprintf("📡 %s • 🕒 %s",
-			article.Source,
-			formatTimeAgo(article.Published))
+		sourceTime := fmt.Sprintf("📡 %s • 🕒 %s", article.Source, timeAgo)
+		if badge := mediaBadge(article); badge != "" {
+			sourceTime += " • " + badge
+		}
+		if isNew {
+			sourceTime += " • 🆕"
+		}
 		articleContent.WriteString("\n" + metaStyle.Render(sourceTime))
 
-		// Description
-		if article.Description != "" {
-			desc := article.Description
-			if len(desc) > 120 {
-				desc = desc[:117] + "..."
-			}
-			articleContent.WriteString("\n" + descStyle.Render("💬 " + desc))
+		// Summary or description, or where a search matched
+		desc, maxLen := article.Summary, 240
+		if desc == "" {
+			desc, maxLen = article.Description, 120
+		}
+		if hasSnippet {
+			inline := descStyle.UnsetMarginTop()
+			matchStyle := inline.
+				Foreground(m.theme.MatchFg).
+				Background(m.theme.MatchBg)
+			line := inline.Render("🔎 ") + renderSnippet(snippet, inline, matchStyle)
+			articleContent.WriteString("\n" + lipgloss.NewStyle().MarginTop(1).Render(line))
+		} else if desc != "" {
+			desc = textutil.Truncate(desc, maxLen)
+			articleContent.WriteString("\n" + descStyle.Render("💬 "+desc))
 		}
 
-		b.WriteString(containerStyle.Render(articleContent.String()))
-		b.WriteString("\n")
-	}
+		return containerStyle.Render(articleContent.String())
+	})
+}
 
-	// Enhanced footer
+// renderIndexFooter renders the key hints below the index
+func (m Model) renderIndexFooter() string {
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
-		Background(lipgloss.Color("#1A1A1A")).
+		Foreground(m.theme.Muted).
+		Background(m.theme.Background).
 		Padding(1).
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#3C3C3C")).
+		BorderForeground(m.theme.Border).
 		Width(m.windowWidth - 4).
 		Align(lipgloss.Center).
 		MarginTop(1)
 
-	footer := "🖱️  Mouse & scroll wheel supported • ⏎ Enter to read • ↑/↓ or j/k to navigate • g/G first/last • h help • q quit"
-	if m.showHelp {
-		footer = "📖 Navigation: ↑/↓ or j/k or mouse wheel • ⏎ Enter: read article • 🖱️ Click: select & read • g/G: first/last • ESC: back • q: quit • h: toggle help"
+	keys := m.keymap
+	footer := fmt.Sprintf("%d/%d • 🖱️  Mouse & scroll wheel supported • %s read • %s/%s navigate • %s search • %s filter • %s help • %s quit",
+		m.selectedIndex+1, len(m.visible),
+		keys.hint(actionOpen), keys.hint(actionUp), keys.hint(actionDown), keys.hint(actionSearch),
+		keys.hint(actionFilter), keys.hint(actionHelp), keys.hint(actionQuit))
+	if m.statusMessage != "" {
+		footer = m.statusMessage
 	}
 
-	b.WriteString(footerStyle.Render(footer))
-
-	return b.String()
+	return footerStyle.Render(footer)
 }
 
 // renderArticle renders the selected article
 func (m Model) renderArticle() string {
-	if m.selectedIndex >= len(m.articles) {
+	article, ok := m.selectedArticle()
+	if !ok {
 		return "Error: Invalid article selection"
 	}
 
-	article := m.articles[m.selectedIndex]
+	// Content is normally set in Update; this only happens when the
+	// selection changed underneath the article view
+	if m.viewport.key != article.Key() {
+		m.viewport.SetContent(article.Key(), m.articleContent(article))
+	}
+
+	// Create scrollable view
+	return m.renderScrollableContent()
+}
+
+// articleContent renders an article's markdown with glamour, wrapped to
+// the window
+func (m Model) articleContent(article news.Article) string {
+	return m.cache.markdown(article.Key(), m.articleWidth(), func() string {
+		return m.articleMarkdown(article)
+	})
+}
+
+// articleWidth returns the wrap width for the article view
+func (m Model) articleWidth() int {
+	limit := maxArticleWidth
+	if m.readerMode {
+		limit = m.readerWidth
+	}
+	if width := m.windowWidth - 4; width < limit {
+		return width
+	}
+	return limit
+}
+
+// articleHeight returns the number of content lines in the article view
+func (m Model) articleHeight() int {
+	// Header, footer and the blank lines around the content take 8 rows
+	return m.windowHeight - 8 - strings.Count(m.renderTabBar(), "\n")
+}
 
-	// Generate markdown content for the article
+// articleMarkdown generates the markdown shown for an article. It is
+// cached, so it leaves out anything that changes over time, such as how
+// long ago the article was published.
+func (m Model) articleMarkdown(article news.Article) string {
 	var md strings.Builder
 
 	md.WriteString(fmt.Sprintf("# %s\n\n", article.Title))
 	md.WriteString(fmt.Sprintf("**Source:** %s\n", article.Source))
-	md.WriteString(fmt.Sprintf("**Published:** %s (%s)\n",
-		article.Published.Format("Monday, January 2, 2006 at 15:04"),
-		formatTimeAgo(article.Published)))
-	md.WriteString(fmt.Sprintf("**URL:** %s\n\n", article.Link))
+	if authors := article.AuthorNames(); authors != "" {
+		md.WriteString(fmt.Sprintf("**By:** %s\n", authors))
+	}
+	md.WriteString(fmt.Sprintf("**Published:** %s\n",
+		article.Published.Format("Monday, January 2, 2006 at 15:04")))
+	if !article.Updated.IsZero() {
+		md.WriteString(fmt.Sprintf("**Updated:** %s\n",
+			article.Updated.Format("Monday, January 2, 2006 at 15:04")))
+	}
+	md.WriteString(fmt.Sprintf("**URL:** %s\n", article.Link))
+	if article.CommentsURL != "" {
+		md.WriteString(fmt.Sprintf("**Comments:** %s\n", article.CommentsURL))
+	}
+	md.WriteString("\n")
 
 	if len(article.Categories) > 0 {
 		md.WriteString("**Categories:** ")
@@ -383,144 +884,216 @@ func (m Model) renderArticle() string {
 		md.WriteString(imagePlaceholder)
 	}
 
+	if article.Summary != "" {
+		md.WriteString(fmt.Sprintf("> %s\n\n", article.Summary))
+	}
+
 	if article.Content != "" {
 		md.WriteString(fmt.Sprintf("%s\n\n", article.Content))
 	} else if article.Description != "" {
 		md.WriteString(fmt.Sprintf("%s\n\n", article.Description))
 	}
 
-	// Render with glamour
-	rendered, err := m.renderer.Render(md.String())
-	if err != nil {
-		rendered = md.String() // Fallback to plain text
+	if article.Copyright != "" {
+		md.WriteString(fmt.Sprintf("*© %s*\n", strings.TrimPrefix(article.Copyright, "©")))
 	}
 
-	m.viewport.content = rendered
-
-	// Create scrollable view
-	return m.renderScrollableContent()
+	return md.String()
 }
 
 // renderScrollableContent renders content with scrolling
 func (m Model) renderScrollableContent() string {
-	lines := strings.Split(m.viewport.content, "\n")
-
-	start := m.viewport.scrollOffset
-	end := start + m.viewport.height - 3 // Leave space for header and footer
-
-	if start < 0 {
-		start = 0
-	}
-	if end > len(lines) {
-		end = len(lines)
-	}
-	if start >= end {
-		start = end - 1
-		if start < 0 {
-			start = 0
-		}
-	}
-
-	var visible []string
-	if start < len(lines) {
-		visible = lines[start:end]
-	}
 
 	// Enhanced header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#5F87D7")).
+		Foreground(m.theme.HeaderFg).
+		Background(m.theme.HeaderBg).
 		Padding(0, 2).
 		BorderStyle(lipgloss.RoundedBorder()).
 		Width(m.windowWidth - 4).
 		Align(lipgloss.Center)
 
-	article := m.articles[m.selectedIndex]
-	header := fmt.Sprintf("📖 Article %d of %d • %s",
-		m.selectedIndex+1, len(m.articles), article.Source)
-
-	// Calculate scroll percentage
-	scrollPercent := 0
-	if len(lines) > m.viewport.height {
-		scrollPercent = int(float64(start) / float64(len(lines)-m.viewport.height) * 100)
+	article, _ := m.selectedArticle()
+	header := fmt.Sprintf("📖 Article %d of %d • %s • 🕒 %s",
+		m.selectedIndex+1, len(m.visible), article.Source, formatTimeAgo(article.Published))
+	if m.readerMode {
+		header += fmt.Sprintf(" • ⏱ %d min read", readingMinutes(article))
 	}
+	if m.contentLoading() {
+		header += " • ⏳ loading full text…"
+	}
+
+	scrollPercent := m.viewport.Percent()
 
 	// Enhanced footer with scroll indicator
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
-		Background(lipgloss.Color("#1A1A1A")).
+		Foreground(m.theme.Muted).
+		Background(m.theme.Background).
 		Padding(0, 2).
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#3C3C3C")).
+		BorderForeground(m.theme.Border).
 		Width(m.windowWidth - 4).
 		Align(lipgloss.Center)
 
-	footer := fmt.Sprintf("📊 %d%% • 🖱️ Mouse wheel supported • ⬅ ESC back • ↑/↓ scroll • h help", scrollPercent)
-	if m.showHelp {
-		footer = "🖱️ Mouse wheel or ↑/↓ j/k: scroll • PgUp/PgDn: page • g/G: top/bottom • ⬅ ESC: back to index • q: quit • h: toggle help"
+	keys := m.keymap
+	footer := fmt.Sprintf("📊 %d%% • 🖱️ Mouse wheel supported • ⬅ %s back • %s/%s scroll • %s help",
+		scrollPercent, keys.hint(actionBack), keys.hint(actionUp), keys.hint(actionDown), keys.hint(actionHelp))
+	if article.HasMedia("") {
+		footer = fmt.Sprintf("📊 %d%% • %s %s play with %s • ⬅ %s back • %s/%s scroll • %s help",
+			scrollPercent, mediaBadge(article), keys.hint(actionPlay), playerCommand()[0],
+			keys.hint(actionBack), keys.hint(actionUp), keys.hint(actionDown), keys.hint(actionHelp))
+	}
+	if m.readerMode {
+		footer = m.renderProgress(article)
+	}
+	if m.statusMessage != "" {
+		footer = m.statusMessage
+	}
+	if m.bodySearch.editing {
+		footer = "🔍 /" + m.bodySearch.query + "▏"
+	}
+	footer = footerStyle.Render(footer)
+
+	// Fit the content between the header and a footer that may wrap
+	tabBar := m.renderTabBar()
+	viewport := m.viewport
+	viewport.SetHeight(m.windowHeight - 5 - lipgloss.Height(footer) - strings.Count(tabBar, "\n"))
+	visible := viewport.VisibleLines()
+
+	if query := m.bodyQuery(); query != "" {
+		highlightStyle := lipgloss.NewStyle().
+			Foreground(m.theme.MatchFg).
+			Background(m.theme.MatchBg)
+		highlighted := make([]string, len(visible))
+		for i, line := range visible {
+			highlighted[i] = highlightLine(line, query, highlightStyle)
+		}
+		visible = highlighted
+	}
+
+	if margin := m.readerMargin(); margin > 0 {
+		indent := strings.Repeat(" ", margin)
+		centered := make([]string, len(visible))
+		for i, line := range visible {
+			centered[i] = indent + line
+		}
+		visible = centered
+	}
+
+	// Keep the footer at the bottom of short articles
+	for len(visible) < viewport.height {
+		visible = append(visible, "")
 	}
 
 	var b strings.Builder
+	b.WriteString(tabBar)
 	b.WriteString(headerStyle.Render(header))
 	b.WriteString("\n\n")
 	b.WriteString(strings.Join(visible, "\n"))
 	b.WriteString("\n\n")
-	b.WriteString(footerStyle.Render(footer))
+	b.WriteString(footer)
 
 	return b.String()
 }
 
 // renderEmpty renders empty state
 func (m Model) renderEmpty() string {
+	tabBar := m.renderTabBar()
 	style := lipgloss.NewStyle().
 		Align(lipgloss.Center).
-		Foreground(lipgloss.Color("241")).
-		Height(m.windowHeight).
+		Foreground(m.theme.Muted).
+		Height(m.windowHeight - strings.Count(tabBar, "\n")).
 		Width(m.windowWidth)
 
-	return style.Render("📭 No articles found\n\nPress 'q' to quit")
+	return tabBar + style.Render(fmt.Sprintf("📭 No articles found\n\nPress %s to quit", m.keymap.hint(actionQuit)))
 }
 
-// updateViewport updates the viewport content
+// renderLoading renders the loading state while the first fetch runs
+func (m Model) renderLoading() string {
+	tabBar := m.renderTabBar()
+	style := lipgloss.NewStyle().
+		Align(lipgloss.Center, lipgloss.Center).
+		Foreground(m.theme.Accent).
+		Height(m.windowHeight - strings.Count(tabBar, "\n")).
+		Width(m.windowWidth)
+
+	return tabBar + style.Render(fmt.Sprintf("📰 %s\n\n%s\n\nPress %s to quit", m.title, m.liveStatus(), m.keymap.hint(actionQuit)))
+}
+
+// updateViewport renders the selected article into the viewport, sized
+// to the window
 func (m *Model) updateViewport() {
-	if m.currentView == ArticleView {
-		// Content will be generated in renderArticle
-		// Always start at the top when entering article view
-		m.viewport.scrollOffset = 0
-		m.viewport.height = m.windowHeight - 4 // Account for header/footer
+	if m.currentView != ArticleView {
+		return
+	}
+
+	m.viewport.SetHeight(m.articleHeight())
+	if article, ok := m.selectedArticle(); ok {
+		m.viewport.SetContent(article.Key(), m.articleContent(article))
 	}
 }
 
+// openArticle shows the selected article where it was last left and
+// starts downloading its full text
+func (m *Model) openArticle() tea.Cmd {
+	if _, ok := m.selectedArticle(); !ok {
+		return nil
+	}
+
+	m.currentView = ArticleView
+	m.updateViewport()
+	m.viewport.ScrollTo(0)
+	m.restorePosition()
+	return m.loadFullContent(false)
+}
+
 // Scrolling methods
 func (m *Model) scrollUp() {
-	if m.viewport.scrollOffset > 0 {
-		m.viewport.scrollOffset--
-	}
+	m.viewport.ScrollBy(-1)
 }
 
 func (m *Model) scrollDown() {
-	lines := strings.Count(m.viewport.content, "\n")
-	maxScroll := lines - m.viewport.height + 1
-	if maxScroll > 0 && m.viewport.scrollOffset < maxScroll {
-		m.viewport.scrollOffset++
-	}
+	m.viewport.ScrollBy(1)
 }
 
 func (m *Model) pageUp() {
-	m.viewport.scrollOffset -= m.viewport.height - 2
-	if m.viewport.scrollOffset < 0 {
-		m.viewport.scrollOffset = 0
+	if m.currentView == IndexView {
+		m.moveSelection(-m.cardsPerPage())
+		return
 	}
+	m.viewport.ScrollBy(-(m.viewport.height - 2))
 }
 
 func (m *Model) pageDown() {
-	lines := strings.Count(m.viewport.content, "\n")
-	maxScroll := lines - m.viewport.height + 1
-	m.viewport.scrollOffset += m.viewport.height - 2
-	if maxScroll > 0 && m.viewport.scrollOffset > maxScroll {
-		m.viewport.scrollOffset = maxScroll
+	if m.currentView == IndexView {
+		m.moveSelection(m.cardsPerPage())
+		return
+	}
+	m.viewport.ScrollBy(m.viewport.height - 2)
+}
+
+// moveSelection moves the index selection by n, clamped to the list
+func (m *Model) moveSelection(n int) {
+	if len(m.visible) == 0 {
+		return
+	}
+
+	m.selectedIndex += n
+	if m.selectedIndex >= len(m.visible) {
+		m.selectedIndex = len(m.visible) - 1
+	}
+	if m.selectedIndex < 0 {
+		m.selectedIndex = 0
+	}
+}
+
+// cardsPerPage returns how many cards were drawn in the last frame
+func (m Model) cardsPerPage() int {
+	if n := len(m.layout.cards); n > 1 {
+		return n - 1
 	}
+	return 1
 }
 
 // Helper function for time formatting
@@ -3,8 +3,10 @@ package tui
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"nwcli/pkg/news"
+	"nwcli/pkg/state"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -16,9 +18,43 @@ func LaunchTUI(articles []news.Article, title string) error {
 		return fmt.Errorf("failed to create TUI model: %w", err)
 	}
 
+	return runProgram(model)
+}
+
+// LaunchLiveTUI starts the TUI immediately with the given articles and
+// fetches fresh ones in the background
+func LaunchLiveTUI(articles []news.Article, title string, opts LiveOptions) error {
+	model, err := NewLiveModel(articles, title, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create TUI model: %w", err)
+	}
+
+	return runProgram(model)
+}
+
+// runProgram runs the TUI and records the end of the session
+func runProgram(model *Model) error {
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err = p.Run()
-	return err
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+
+	// Remember when this session ended for the "new since last session"
+	// filter, and the pane sizes and reading positions for the next session
+	session := state.Load()
+	session.LastSession = time.Now()
+	switch final := final.(type) {
+	case Model:
+		session.SplitRatio = final.splitRatio
+		session.Positions = final.positions
+	case *Model:
+		session.SplitRatio = final.splitRatio
+		session.Positions = final.positions
+	}
+	state.Save(session)
+
+	return nil
 }
 
 // ShouldUsePager determines if we should use the pager based on flags and environment
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"nwcli/pkg/news"
+	"nwcli/pkg/state"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// defaultReaderWidth is the text column width in reader mode
+	defaultReaderWidth = 72
+	// minReaderWidth keeps the reader column usable
+	minReaderWidth = 30
+	// wordsPerMinute is the reading speed used for reading time estimates
+	wordsPerMinute = 200
+	// progressBarWidth is the width of the reader mode progress bar
+	progressBarWidth = 24
+)
+
+// moveArticle opens the next (n > 0) or previous (n < 0) article from the
+// article view
+func (m *Model) moveArticle(n int) tea.Cmd {
+	before := m.selectedIndex
+	m.rememberPosition()
+	m.moveSelection(n)
+	if m.selectedIndex == before {
+		if n > 0 {
+			m.statusMessage = "📭 This is the last article"
+		} else {
+			m.statusMessage = "📭 This is the first article"
+		}
+		return nil
+	}
+
+	m.statusMessage = ""
+	m.bodySearch = searchState{}
+	return m.openArticle()
+}
+
+// rememberPosition records how far the open article was read. Articles
+// scrolled back to the top are forgotten.
+func (m *Model) rememberPosition() {
+	if m.currentView != ArticleView || len(m.viewport.lines) == 0 {
+		return
+	}
+	article, ok := m.selectedArticle()
+	if !ok {
+		return
+	}
+
+	if m.viewport.offset == 0 {
+		delete(m.positions, article.Key())
+		return
+	}
+	m.positions[article.Key()] = state.ReadPosition{
+		Progress: float64(m.viewport.offset) / float64(len(m.viewport.lines)),
+		Read:     time.Now(),
+	}
+}
+
+// restorePosition scrolls to where the selected article was left, which
+// survives changes of the window width
+func (m *Model) restorePosition() {
+	article, ok := m.selectedArticle()
+	if !ok {
+		return
+	}
+	if position, ok := m.positions[article.Key()]; ok {
+		m.viewport.ScrollTo(int(position.Progress*float64(len(m.viewport.lines)) + 0.5))
+	}
+}
+
+// readingMinutes estimates how long the article takes to read
+func readingMinutes(article news.Article) int {
+	text := article.Content
+	if text == "" {
+		text = article.Description
+	}
+	words := len(strings.Fields(article.Summary)) + len(strings.Fields(text))
+
+	minutes := (words + wordsPerMinute - 1) / wordsPerMinute
+	if minutes < 1 {
+		minutes = 1
+	}
+	return minutes
+}
+
+// readerMargin returns the left margin that centers the reader column
+func (m Model) readerMargin() int {
+	if !m.readerMode {
+		return 0
+	}
+	if margin := (m.windowWidth - m.articleWidth()) / 2; margin > 0 {
+		return margin
+	}
+	return 0
+}
+
+// renderProgress renders the reader mode footer: a progress bar and the
+// estimated reading time left
+func (m Model) renderProgress(article news.Article) string {
+	percent := m.viewport.Percent()
+	filled := percent * progressBarWidth / 100
+
+	bar := lipgloss.NewStyle().Foreground(m.theme.Accent).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(m.theme.Border).Render(strings.Repeat("─", progressBarWidth-filled))
+
+	left := readingMinutes(article) * (100 - percent) / 100
+	remaining := "done"
+	if percent < 100 {
+		remaining = fmt.Sprintf("⏱ %d min left", left)
+		if left < 1 {
+			remaining = "⏱ < 1 min left"
+		}
+	}
+
+	keys := m.keymap
+	return fmt.Sprintf("%s %d%% • %s • %s prev • %s next • ⬅ %s back",
+		bar, percent, remaining, keys.hint(actionPrevArticle), keys.hint(actionNextArticle), keys.hint(actionBack))
+}
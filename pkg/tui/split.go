@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"strings"
+
+	"nwcli/pkg/news"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// defaultSplitMinWidth is the narrowest terminal that gets the split layout
+	defaultSplitMinWidth = 120
+	// defaultSplitRatio is the share of the width given to the article list
+	defaultSplitRatio = 0.4
+	// minPaneWidth keeps either pane from being resized away
+	minPaneWidth = 30
+	// splitStep is how much one resize key press moves the divider
+	splitStep = 0.05
+)
+
+// isSplit reports whether the index is shown next to an article preview
+func (m Model) isSplit() bool {
+	return m.splitEnabled && m.currentView == IndexView && m.windowWidth >= m.splitMinWidth
+}
+
+// paneWidths returns the widths of the list and preview panes
+func (m Model) paneWidths() (int, int) {
+	list := int(float64(m.windowWidth) * m.splitRatio)
+	if list < minPaneWidth {
+		list = minPaneWidth
+	}
+	if m.windowWidth-list < minPaneWidth {
+		list = m.windowWidth - minPaneWidth
+	}
+	return list, m.windowWidth - list
+}
+
+// resizeSplit moves the divider by delta of the window width
+func (m *Model) resizeSplit(delta float64) {
+	ratio := m.splitRatio + delta
+	if ratio < 0.2 {
+		ratio = 0.2
+	}
+	if ratio > 0.8 {
+		ratio = 0.8
+	}
+	m.splitRatio = ratio
+}
+
+// renderSplit renders the article list and the selected article's preview
+// side by side
+func (m Model) renderSplit() string {
+	listWidth, previewWidth := m.paneWidths()
+
+	// The tab bar spans both panes
+	tabBar := m.renderTabBar()
+	barHeight := strings.Count(tabBar, "\n")
+
+	list := m
+	list.windowWidth = listWidth
+	list.windowHeight -= barHeight
+	left := list.renderIndexBody(barHeight)
+
+	preview := m
+	preview.windowHeight -= barHeight
+
+	return tabBar + lipgloss.JoinHorizontal(lipgloss.Top, left, preview.renderPreview(previewWidth))
+}
+
+// renderPreview renders the selected article in a bordered pane
+func (m Model) renderPreview(width int) string {
+	// Border and padding take four columns
+	innerWidth := width - 4
+
+	content := "📭 Nothing selected"
+	if article, ok := m.selectedArticle(); ok {
+		// Leave room for the padding
+		content = m.previewContent(article, innerWidth-2)
+	}
+
+	height := m.windowHeight - 2
+	lines := strings.Split(content, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	style := lipgloss.NewStyle().
+		Width(innerWidth).
+		Height(height).
+		MaxHeight(height+2).
+		Padding(0, 1).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Accent)
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// previewContent renders an article for the preview pane
+func (m Model) previewContent(article news.Article, width int) string {
+	rendered := m.cache.markdown(article.Key(), width, func() string {
+		return m.articleMarkdown(article)
+	})
+	return strings.Trim(rendered, "\n")
+}
@@ -0,0 +1,347 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"nwcli/pkg/config"
+	"nwcli/pkg/news"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TabProvider loads the initial articles and background refresh options
+// for a tab
+type TabProvider func(tab config.Tab) ([]news.Article, LiveOptions, error)
+
+// tabView holds the per-tab state that is swapped in and out of the Model
+type tabView struct {
+	tab config.Tab
+	// ephemeral tabs (the command's own view) are not persisted
+	ephemeral bool
+	loaded    bool
+
+	title          string
+	articles       []news.Article
+	visible        []int
+	selectedIndex  int
+	search         searchState
+	sourceFilter   string
+	categoryFilter string
+	onlyNew        bool
+	highlight      string
+	snippets       map[string]news.Snippet
+	live           *liveState
+}
+
+// tabsState holds all tabs and the tab prompt
+type tabsState struct {
+	views    []*tabView
+	active   int
+	provider TabProvider
+	prompt   searchState
+}
+
+// enableTabs turns the current view into the first tab and appends the
+// persisted tabs after it
+func (m *Model) enableTabs(provider TabProvider, saved []config.Tab) {
+	current := &tabView{
+		tab:       config.Tab{Name: m.title},
+		ephemeral: true,
+		loaded:    true,
+	}
+
+	m.tabs = &tabsState{
+		views:    []*tabView{current},
+		provider: provider,
+	}
+	m.storeTab()
+
+	for _, tab := range saved {
+		m.tabs.views = append(m.tabs.views, &tabView{tab: tab, title: tab.Name})
+	}
+}
+
+// storeTab copies the active view state from the model into its tab
+func (m *Model) storeTab() {
+	view := m.tabs.views[m.tabs.active]
+	view.title = m.title
+	view.articles = m.articles
+	view.visible = m.visible
+	view.selectedIndex = m.selectedIndex
+	view.search = m.search
+	view.sourceFilter = m.sourceFilter
+	view.categoryFilter = m.categoryFilter
+	view.onlyNew = m.onlyNew
+	view.highlight = m.highlight
+	view.snippets = m.snippets
+	view.live = m.live
+}
+
+// restoreTab copies a tab's view state into the model
+func (m *Model) restoreTab(index int) {
+	view := m.tabs.views[index]
+	m.tabs.active = index
+	m.title = view.title
+	m.articles = view.articles
+	m.visible = view.visible
+	m.selectedIndex = view.selectedIndex
+	m.search = view.search
+	m.sourceFilter = view.sourceFilter
+	m.categoryFilter = view.categoryFilter
+	m.onlyNew = view.onlyNew
+	m.highlight = view.highlight
+	m.snippets = view.snippets
+	m.live = view.live
+}
+
+// switchTab activates another tab, loading it on first use
+func (m *Model) switchTab(index int) tea.Cmd {
+	if m.tabs == nil || index < 0 || index >= len(m.tabs.views) || index == m.tabs.active {
+		return nil
+	}
+
+	m.rememberPosition()
+	m.storeTab()
+	return m.openTab(index)
+}
+
+// openTab shows the tab at index, loading it on first use
+func (m *Model) openTab(index int) tea.Cmd {
+	m.restoreTab(index)
+	m.currentView = IndexView
+	m.bodySearch = searchState{}
+	m.filterMenu = nil
+
+	view := m.tabs.views[index]
+	if view.loaded {
+		return nil
+	}
+
+	view.loaded = true
+	articles, opts, err := m.tabs.provider(view.tab)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("⚠️  %s: %v", view.tab.Name, err)
+		return nil
+	}
+
+	m.articles = articles
+	m.selectedIndex = 0
+	m.setHighlight(opts.Highlight)
+	m.applyFilters()
+	if opts.Fetcher != nil {
+		m.live = &liveState{
+			options: opts,
+			sources: opts.Fetcher.GetSources(),
+			newKeys: make(map[string]bool),
+		}
+	}
+	if m.content == nil {
+		m.content = newContentState(opts.Content)
+	}
+	m.storeTab()
+
+	return m.startRefresh(false)
+}
+
+// updateBackgroundTab applies a background refresh message to the tab
+// that owns it, even when another tab is active
+func (m Model) updateBackgroundTab(owner *liveState, msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.tabs == nil || owner == nil {
+		return m, nil
+	}
+
+	for i, view := range m.tabs.views {
+		if view.live != owner {
+			continue
+		}
+
+		active := m.tabs.active
+		m.storeTab()
+		m.restoreTab(i)
+		updated, cmd := m.updateLive(msg)
+		m = updated.(Model)
+		m.storeTab()
+		m.restoreTab(active)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// closeTab closes the active tab, keeping at least one open, and loads
+// the tab taking its place if it was never shown
+func (m *Model) closeTab() tea.Cmd {
+	if m.tabs == nil || len(m.tabs.views) < 2 {
+		m.statusMessage = "Cannot close the last tab"
+		return nil
+	}
+
+	index := m.tabs.active
+	m.tabs.views = append(m.tabs.views[:index], m.tabs.views[index+1:]...)
+	if index >= len(m.tabs.views) {
+		index = len(m.tabs.views) - 1
+	}
+	m.saveTabs()
+	return m.openTab(index)
+}
+
+// moveTab moves the active tab left (dir < 0) or right (dir > 0)
+func (m *Model) moveTab(dir int) {
+	if m.tabs == nil {
+		return
+	}
+
+	from := m.tabs.active
+	to := from + dir
+	if to < 0 || to >= len(m.tabs.views) {
+		return
+	}
+
+	m.storeTab()
+	views := m.tabs.views
+	views[from], views[to] = views[to], views[from]
+	m.tabs.active = to
+	m.saveTabs()
+}
+
+// addTab parses a tab spec, appends the tab and switches to it
+func (m *Model) addTab(spec string) tea.Cmd {
+	tab, err := parseTabSpec(spec)
+	if err != nil {
+		m.statusMessage = "⚠️  " + err.Error()
+		return nil
+	}
+
+	m.tabs.views = append(m.tabs.views, &tabView{tab: tab, title: tab.Name})
+	m.saveTabs()
+	return m.switchTab(len(m.tabs.views) - 1)
+}
+
+// saveTabs persists the non-ephemeral tabs in the config file
+func (m *Model) saveTabs() {
+	cfg, err := config.Load()
+	if err != nil {
+		m.statusMessage = "⚠️  " + err.Error()
+		return
+	}
+
+	cfg.TUI.Tabs = nil
+	for _, view := range m.tabs.views {
+		if !view.ephemeral {
+			cfg.TUI.Tabs = append(cfg.TUI.Tabs, view.tab)
+		}
+	}
+
+	if err := cfg.Save(); err != nil {
+		m.statusMessage = "⚠️  " + err.Error()
+	}
+}
+
+// parseTabSpec parses "kind:value" (e.g. "country:de", "category:sports",
+// "source:NOS", "query:AI"). A bare country code opens a country tab and
+// any other bare text a saved query.
+func parseTabSpec(spec string) (config.Tab, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return config.Tab{}, fmt.Errorf("empty tab")
+	}
+
+	kind, value, found := strings.Cut(spec, ":")
+	if !found {
+		value = spec
+		kind = config.TabQuery
+		for _, country := range news.GetAvailableCountries() {
+			if strings.EqualFold(spec, country) {
+				kind = config.TabCountry
+			}
+		}
+	}
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return config.Tab{}, fmt.Errorf("tab %q has no value", spec)
+	}
+
+	tab := config.Tab{Kind: kind, Value: value}
+	switch kind {
+	case config.TabCountry:
+		tab.Value = strings.ToLower(value)
+		tab.Name = strings.ToUpper(value)
+	case config.TabCategory:
+		tab.Name = "🏷️ " + value
+	case config.TabSource:
+		tab.Name = "📡 " + value
+	case config.TabQuery:
+		tab.Name = "🔍 " + value
+	default:
+		return config.Tab{}, fmt.Errorf("unknown tab kind %q (use country, category, source or query)", kind)
+	}
+
+	return tab, nil
+}
+
+// updateTabPrompt handles key presses while the add-tab prompt is open
+func (m Model) updateTabPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := &m.tabs.prompt
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		*prompt = searchState{}
+	case tea.KeyEnter:
+		spec := prompt.query
+		*prompt = searchState{}
+		return m, m.addTab(spec)
+	case tea.KeyBackspace:
+		if runes := []rune(prompt.query); len(runes) > 0 {
+			prompt.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		prompt.query += " "
+	case tea.KeyRunes:
+		prompt.query += string(msg.Runes)
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// renderTabBar renders the tab bar, or nothing when tabs are disabled
+func (m Model) renderTabBar() string {
+	if m.tabs == nil {
+		return ""
+	}
+
+	activeStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.theme.HeaderFg).
+		Background(m.theme.HeaderBg).
+		Padding(0, 1)
+	inactiveStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		Padding(0, 1)
+
+	var tabs []string
+	for i, view := range m.tabs.views {
+		label := fmt.Sprintf("%d %s", i+1, view.tab.Name)
+		if view.live != nil && view.live.fetching {
+			label += " " + spinnerFrames[view.live.frame]
+		}
+		if i == m.tabs.active {
+			tabs = append(tabs, activeStyle.Render(label))
+		} else {
+			tabs = append(tabs, inactiveStyle.Render(label))
+		}
+	}
+
+	bar := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+	if m.tabs.prompt.editing {
+		promptStyle := lipgloss.NewStyle().Foreground(m.theme.Highlight).Bold(true)
+		bar += "  " + promptStyle.Render("➕ "+m.tabs.prompt.query+"▏")
+	}
+
+	return bar + "\n"
+}
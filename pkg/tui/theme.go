@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme sets the TUI colors and the glamour style used for articles
+type Theme struct {
+	// Glamour is a glamour style name (dark, light, dracula, ...) or the
+	// path to a glamour JSON style file
+	Glamour string `json:"glamour"`
+
+	HeaderFg           lipgloss.Color `json:"header_fg"`
+	HeaderBg           lipgloss.Color `json:"header_bg"`
+	SubHeader          lipgloss.Color `json:"sub_header"`
+	Accent             lipgloss.Color `json:"accent"`
+	Highlight          lipgloss.Color `json:"highlight"`
+	Title              lipgloss.Color `json:"title"`
+	Text               lipgloss.Color `json:"text"`
+	TextSelected       lipgloss.Color `json:"text_selected"`
+	Muted              lipgloss.Color `json:"muted"`
+	MutedSelected      lipgloss.Color `json:"muted_selected"`
+	Border             lipgloss.Color `json:"border"`
+	BorderSelected     lipgloss.Color `json:"border_selected"`
+	Background         lipgloss.Color `json:"background"`
+	BackgroundSelected lipgloss.Color `json:"background_selected"`
+	MatchFg            lipgloss.Color `json:"match_fg"`
+	MatchBg            lipgloss.Color `json:"match_bg"`
+}
+
+// themes are the built-in themes
+var themes = map[string]Theme{
+	"dark": {
+		Glamour:            "dark",
+		HeaderFg:           "#FFFFFF",
+		HeaderBg:           "#5F87D7",
+		SubHeader:          "#8888AA",
+		Accent:             "#5F87D7",
+		Highlight:          "#FFB6D9",
+		Title:              "#FFFFFF",
+		Text:               "#CCCCCC",
+		TextSelected:       "#EEEEEE",
+		Muted:              "#888888",
+		MutedSelected:      "#BBBBBB",
+		Border:             "#3C3C3C",
+		BorderSelected:     "#FF6B9D",
+		Background:         "#1A1A1A",
+		BackgroundSelected: "#2D1B4E",
+		MatchFg:            "#1A1A1A",
+		MatchBg:            "#FFD75F",
+	},
+	"light": {
+		Glamour:            "light",
+		HeaderFg:           "#FFFFFF",
+		HeaderBg:           "#3A6EA5",
+		SubHeader:          "#5A5A7A",
+		Accent:             "#3A6EA5",
+		Highlight:          "#B0306A",
+		Title:              "#1A1A1A",
+		Text:               "#3A3A3A",
+		TextSelected:       "#1A1A1A",
+		Muted:              "#6A6A6A",
+		MutedSelected:      "#4A4A4A",
+		Border:             "#C8C8C8",
+		BorderSelected:     "#B0306A",
+		Background:         "#F7F7F7",
+		BackgroundSelected: "#FBE4EE",
+		MatchFg:            "#1A1A1A",
+		MatchBg:            "#FFE27A",
+	},
+	"dracula": {
+		Glamour:            "dracula",
+		HeaderFg:           "#F8F8F2",
+		HeaderBg:           "#6272A4",
+		SubHeader:          "#6272A4",
+		Accent:             "#BD93F9",
+		Highlight:          "#FF79C6",
+		Title:              "#F8F8F2",
+		Text:               "#E0E0DA",
+		TextSelected:       "#F8F8F2",
+		Muted:              "#6272A4",
+		MutedSelected:      "#8BE9FD",
+		Border:             "#44475A",
+		BorderSelected:     "#FF79C6",
+		Background:         "#282A36",
+		BackgroundSelected: "#44475A",
+		MatchFg:            "#282A36",
+		MatchBg:            "#F1FA8C",
+	},
+}
+
+// ThemeNames returns the names of the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTheme returns the named built-in theme or loads a JSON theme file.
+// An empty name or "auto" picks dark or light from the terminal
+// background; a theme file only needs the colors it changes from that.
+func LoadTheme(name string) (Theme, error) {
+	base := themes["light"]
+	if lipgloss.HasDarkBackground() {
+		base = themes["dark"]
+	}
+
+	if name == "" || name == "auto" {
+		return base, nil
+	}
+	if theme, ok := themes[name]; ok {
+		return theme, nil
+	}
+	if !strings.HasSuffix(name, ".json") {
+		return Theme{}, fmt.Errorf("unknown theme %q (available: auto, %s, or a .json file)",
+			name, strings.Join(ThemeNames(), ", "))
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme: %w", err)
+	}
+
+	theme := base
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme %s: %w", name, err)
+	}
+
+	return theme, nil
+}
+
+// glamourOption returns the glamour option for the theme's article style
+func (t Theme) glamourOption() glamour.TermRendererOption {
+	if strings.HasSuffix(t.Glamour, ".json") {
+		return glamour.WithStylesFromJSONFile(t.Glamour)
+	}
+	return glamour.WithStandardStyle(t.Glamour)
+}
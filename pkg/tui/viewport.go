@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+)
+
+const (
+	// maxArticleWidth caps the article text width for readability
+	maxArticleWidth = 100
+	// maxCachedCards bounds the index card cache
+	maxCachedCards = 2000
+	// maxCachedArticles bounds the rendered article cache
+	maxCachedArticles = 200
+)
+
+// renderCache memoizes glamour renderings per article and width, and index
+// cards per article and display state. It is shared by pointer because
+// View works on a copy of the model.
+type renderCache struct {
+	style     glamour.TermRendererOption
+	linkify   bool
+	renderers map[int]*glamour.TermRenderer
+	articles  map[string]string
+	cards     map[string]string
+}
+
+// newRenderCache creates an empty cache rendering with the given glamour
+// style, turning URLs into hyperlinks when linkify is set
+func newRenderCache(style glamour.TermRendererOption, linkify bool) *renderCache {
+	return &renderCache{
+		style:     style,
+		linkify:   linkify,
+		renderers: make(map[int]*glamour.TermRenderer),
+		articles:  make(map[string]string),
+		cards:     make(map[string]string),
+	}
+}
+
+// markdown renders md for the article identified by key, wrapped to width
+func (c *renderCache) markdown(key string, width int, md func() string) string {
+	cacheKey := fmt.Sprintf("%s@%d", key, width)
+	if rendered, ok := c.articles[cacheKey]; ok {
+		return rendered
+	}
+
+	source := md()
+	rendered := source // Fallback to plain text
+	if renderer, err := c.renderer(width); err == nil {
+		if out, err := renderer.Render(source); err == nil {
+			rendered = out
+		}
+	}
+	if c.linkify {
+		rendered = linkifyLines(rendered)
+	}
+
+	if len(c.articles) >= maxCachedArticles {
+		c.articles = make(map[string]string)
+	}
+	c.articles[cacheKey] = rendered
+	return rendered
+}
+
+// renderer returns a glamour renderer wrapping at width
+func (c *renderCache) renderer(width int) (*glamour.TermRenderer, error) {
+	if renderer, ok := c.renderers[width]; ok {
+		return renderer, nil
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		c.style,
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create renderer: %w", err)
+	}
+
+	c.renderers[width] = renderer
+	return renderer, nil
+}
+
+// card returns the cached index card for key, rendering it on a miss
+func (c *renderCache) card(key string, render func() string) string {
+	if card, ok := c.cards[key]; ok {
+		return card
+	}
+
+	if len(c.cards) >= maxCachedCards {
+		c.cards = make(map[string]string)
+	}
+
+	card := render()
+	c.cards[key] = card
+	return card
+}
+
+// forget drops every cached rendering of an article, e.g. after its
+// content changed
+func (c *renderCache) forget(articleKey string) {
+	prefix := articleKey + "@"
+	for key := range c.articles {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.articles, key)
+		}
+	}
+	prefix = articleKey + "|"
+	for key := range c.cards {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.cards, key)
+		}
+	}
+}
+
+// Viewport is a scrollable window over pre-rendered lines
+type Viewport struct {
+	// key identifies the article the content belongs to
+	key    string
+	lines  []string
+	offset int
+	height int
+}
+
+// SetContent replaces the content, keeping the scroll position when the
+// same article is shown again (e.g. after a resize)
+func (v *Viewport) SetContent(key, content string) {
+	if key != v.key {
+		v.offset = 0
+	}
+	v.key = key
+	v.lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	v.ScrollTo(v.offset)
+}
+
+// SetHeight changes the number of visible lines
+func (v *Viewport) SetHeight(height int) {
+	if height < 1 {
+		height = 1
+	}
+	v.height = height
+	v.ScrollTo(v.offset)
+}
+
+// maxOffset returns the largest offset that still fills the viewport
+func (v Viewport) maxOffset() int {
+	if max := len(v.lines) - v.height; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// ScrollTo moves the first visible line to offset, clamped to the content
+func (v *Viewport) ScrollTo(offset int) {
+	if offset > v.maxOffset() {
+		offset = v.maxOffset()
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	v.offset = offset
+}
+
+// ScrollBy scrolls by n lines (negative scrolls up)
+func (v *Viewport) ScrollBy(n int) {
+	v.ScrollTo(v.offset + n)
+}
+
+// GotoBottom scrolls to the end of the content
+func (v *Viewport) GotoBottom() {
+	v.ScrollTo(v.maxOffset())
+}
+
+// VisibleLines returns the lines currently in view
+func (v Viewport) VisibleLines() []string {
+	end := v.offset + v.height
+	if end > len(v.lines) {
+		end = len(v.lines)
+	}
+	return v.lines[v.offset:end]
+}
+
+// Percent returns how far the viewport is scrolled, from 0 to 100
+func (v Viewport) Percent() int {
+	if v.maxOffset() == 0 {
+		return 100
+	}
+	return v.offset * 100 / v.maxOffset()
+}
+
+// indexLayout records where the index cards were drawn in the last frame,
+// so the selection can be kept in view and mouse clicks mapped to cards
+type indexLayout struct {
+	// offset is the position in visible of the first card drawn
+	offset int
+	cards  []cardBounds
+}
+
+// cardBounds is the screen rows [top, bottom) occupied by a card
+type cardBounds struct {
+	top, bottom int
+	index       int
+}
+
+// cardAt returns the visible index of the card drawn at screen row y
+func (l *indexLayout) cardAt(y int) (int, bool) {
+	for _, card := range l.cards {
+		if y >= card.top && y < card.bottom {
+			return card.index, true
+		}
+	}
+	return 0, false
+}